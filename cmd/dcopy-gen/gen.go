@@ -0,0 +1,714 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: gen.go
+ * @time: 2026/10/19 11:02
+ * @project: deepcopy
+ */
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/generalzgd/deepcopy/dcopy"
+)
+
+const dcopyImportPath = "github.com/generalzgd/deepcopy/dcopy"
+
+type config struct {
+	types     []string
+	froms     []string
+	fieldType string
+	timeFmt   string
+	timeType  string
+	omitempty bool
+	// nilAsEmpty nil的map/slice输出为空map/slice
+	nilAsEmpty bool
+	// keepZeroPtr omitempty时保留指向0值的指针
+	keepZeroPtr bool
+}
+
+var fieldTypes = map[string]dcopy.FieldType{
+	"idle":   dcopy.FieldType_Idle,
+	"origin": dcopy.FieldType_Origin,
+	"json":   dcopy.FieldType_Json,
+	"xorm":   dcopy.FieldType_Xorm,
+	"gorm":   dcopy.FieldType_Gorm,
+	"form":   dcopy.FieldType_Form,
+}
+
+var fieldTypeNames = map[string]string{
+	"idle":   "dcopy.FieldType_Idle",
+	"origin": "dcopy.FieldType_Origin",
+	"json":   "dcopy.FieldType_Json",
+	"xorm":   "dcopy.FieldType_Xorm",
+	"gorm":   "dcopy.FieldType_Gorm",
+	"form":   "dcopy.FieldType_Form",
+}
+
+type copyPair struct {
+	dest, from string
+}
+
+type generator struct {
+	pkg  *pkgInfo
+	cfg  config
+	opts []dcopy.CopyOption
+	buf  bytes.Buffer
+	tmp  int    // 临时变量序号
+	pre  string // 辅助函数前缀，避免同一个包中多次生成时重名
+
+	imports  map[string]struct{}
+	toMaps   map[string]bool
+	fromMaps map[string]bool
+	copies   map[copyPair]bool
+	queue    []func() error
+}
+
+func generate(dir string, cfg config) ([]byte, error) {
+	if len(cfg.froms) > 0 && len(cfg.froms) != len(cfg.types) {
+		return nil, fmt.Errorf("-from must list as many types as -type")
+	}
+	if _, ok := fieldTypes[cfg.fieldType]; !ok {
+		return nil, fmt.Errorf("unknown -fieldtype %q", cfg.fieldType)
+	}
+	if cfg.timeType != "string" && cfg.timeType != "int64" {
+		return nil, fmt.Errorf("unknown -timetype %q", cfg.timeType)
+	}
+	pkg, err := loadPackage(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		pkg:      pkg,
+		cfg:      cfg,
+		imports:  map[string]struct{}{dcopyImportPath: {}},
+		toMaps:   map[string]bool{},
+		fromMaps: map[string]bool{},
+		copies:   map[copyPair]bool{},
+		pre:      "dcopyGen" + cfg.types[0],
+	}
+	g.opts = []dcopy.CopyOption{
+		dcopy.WithFieldType(fieldTypes[cfg.fieldType]),
+		dcopy.WithOmitempty(cfg.omitempty),
+		dcopy.WithNilAsEmpty(cfg.nilAsEmpty),
+		dcopy.WithKeepZeroPtr(cfg.keepZeroPtr),
+	}
+
+	g.printf("var %sOpts = []dcopy.CopyOption{\n", g.pre)
+	g.printf("dcopy.WithFieldType(%s),\n", fieldTypeNames[cfg.fieldType])
+	g.printf("dcopy.WithOmitempty(%v),\n", cfg.omitempty)
+	if cfg.nilAsEmpty {
+		g.printf("dcopy.WithNilAsEmpty(true),\n")
+	}
+	if cfg.keepZeroPtr {
+		g.printf("dcopy.WithKeepZeroPtr(true),\n")
+	}
+	g.printf("dcopy.WithTimeFormatStr(%q),\n", cfg.timeFmt)
+	if cfg.timeType == "int64" {
+		g.printf("dcopy.WithTimeValType(dcopy.TimeValType_Int64),\n")
+	} else {
+		g.printf("dcopy.WithTimeValType(dcopy.TimeValType_String),\n")
+	}
+	g.printf("}\n\n")
+
+	for i, name := range cfg.types {
+		if _, err := pkg.lookupStruct(name); err != nil {
+			return nil, err
+		}
+		g.printf("// %sToMap 等价于 dcopy.InstanceToMap(from, %sOpts...)\n", name, g.pre)
+		g.printf("func %sToMap(from *%s) (map[string]interface{}, error) {\n", name, name)
+		g.printf("out := map[string]interface{}{}\n")
+		g.printf("if err := %s(out, from); err != nil {\nreturn nil, err\n}\n", g.toMapFunc(name))
+		g.printf("return out, nil\n}\n\n")
+
+		g.printf("// %sFromMap 等价于 dcopy.InstanceFromMap(dest, from, %sOpts...)\n", name, g.pre)
+		g.printf("func %sFromMap(dest *%s, from map[string]interface{}) error {\n", name, name)
+		g.printf("return %s(dest, from)\n}\n\n", g.fromMapFunc(name))
+
+		if len(cfg.froms) > 0 {
+			from := cfg.froms[i]
+			if _, err := pkg.lookupStruct(from); err != nil {
+				return nil, err
+			}
+			g.printf("// %sFrom%s 等价于 dcopy.StructCopy(dest, from)\n", name, from)
+			g.printf("func %sFrom%s(dest *%s, from *%s) error {\n", name, from, name, from)
+			g.printf("return %s(dest, from)\n}\n\n", g.copyFunc(name, from))
+		}
+	}
+
+	// 生成嵌套结构体的辅助函数
+	for len(g.queue) > 0 {
+		fn := g.queue[0]
+		g.queue = g.queue[1:]
+		if err := fn(); err != nil {
+			return nil, err
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by dcopy-gen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", pkg.name)
+	paths := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	fmt.Fprintf(&out, "import (\n")
+	for _, path := range paths {
+		fmt.Fprintf(&out, "%q\n", path)
+	}
+	fmt.Fprintf(&out, ")\n\n")
+	out.Write(g.buf.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %v", err)
+	}
+	return src, nil
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) newVar(prefix string) string {
+	g.tmp += 1
+	return prefix + strconv.Itoa(g.tmp)
+}
+
+// useType 记录类型表达式中引用到的包
+func (g *generator) useType(t *typeInfo) string {
+	for _, name := range t.pkgs {
+		if path, ok := g.pkg.imports[name]; ok {
+			g.imports[path] = struct{}{}
+		}
+	}
+	return t.expr
+}
+
+func (g *generator) toMapFunc(name string) string {
+	fn := g.pre + name + "ToMap"
+	if !g.toMaps[name] {
+		g.toMaps[name] = true
+		g.queue = append(g.queue, func() error { return g.genToMap(fn, name) })
+	}
+	return fn
+}
+
+func (g *generator) fromMapFunc(name string) string {
+	fn := g.pre + name + "FromMap"
+	if !g.fromMaps[name] {
+		g.fromMaps[name] = true
+		g.queue = append(g.queue, func() error { return g.genFromMap(fn, name) })
+	}
+	return fn
+}
+
+func (g *generator) copyFunc(dest, from string) string {
+	fn := g.pre + "Copy" + dest + "From" + from
+	pair := copyPair{dest: dest, from: from}
+	if !g.copies[pair] {
+		g.copies[pair] = true
+		g.queue = append(g.queue, func() error { return g.genCopy(fn, dest, from) })
+	}
+	return fn
+}
+
+// ---------------------------------------------------------------- InstanceToMap
+
+func (g *generator) genToMap(fn, name string) error {
+	st, err := g.pkg.lookupStruct(name)
+	if err != nil {
+		return err
+	}
+	g.printf("func %s(dest map[string]interface{}, from *%s) error {\n", fn, name)
+	for _, field := range st.fields {
+		if !field.exported && !field.anonymous {
+			continue
+		}
+		key, omitempty, ignore := dcopy.FieldTagName(field.structField(), g.opts...)
+		if ignore {
+			continue
+		}
+		g.emitToMap(strconv.Quote(key), "from."+field.name, field.tp, omitempty, g.squash(field) || g.inlineMap(field))
+	}
+	g.printf("return nil\n}\n\n")
+	return nil
+}
+
+func (g *generator) emitToMap(key, val string, t *typeInfo, omitempty, anonymous bool) {
+	switch t.kind {
+	case kindPtr:
+		// nil指针 -> nil，omitempty或展开的匿名组合时忽略
+		g.printf("if %s != nil {\n", val)
+		g.emitToMap(key, deref(val), t.elem, omitempty && !g.cfg.keepZeroPtr, anonymous)
+		if omitempty || anonymous {
+			g.printf("}\n")
+		} else {
+			g.printf("} else {\ndest[%s] = nil\n}\n", key)
+		}
+	case kindBasic:
+		zero, conv := basicZero(t, val)
+		if omitempty {
+			g.printf("if %s {\n", zero)
+		}
+		g.printf("dest[%s] = %s\n", key, conv)
+		if omitempty {
+			g.printf("}\n")
+		}
+	case kindTime:
+		if omitempty {
+			g.printf("if !%s.IsZero() {\n", val)
+		}
+		g.printf("dest[%s] = dcopy.FromTime(%s, %sOpts...)\n", key, val, g.pre)
+		if omitempty {
+			g.printf("}\n")
+		}
+	case kindStruct:
+		fn := g.toMapFunc(t.named)
+		if anonymous {
+			g.printf("if err := %s(dest, %s); err != nil {\nreturn err\n}\n", fn, addr(val))
+			return
+		}
+		sub := g.newVar("sub")
+		g.printf("%s := map[string]interface{}{}\n", sub)
+		g.printf("dest[%s] = %s\n", key, sub)
+		g.printf("if err := %s(%s, %s); err != nil {\nreturn err\n}\n", fn, sub, addr(val))
+	case kindInterface:
+		// 同 getBasicValue，自定义类型转换成基础类型
+		if omitempty {
+			g.printf("if !dcopy.IsEmptyValue(%s) {\n", val)
+		}
+		g.printf("dest[%s] = dcopy.BasicValue(%s)\n", key, val)
+		if omitempty {
+			g.printf("}\n")
+		}
+	case kindSlice, kindMap:
+		if anonymous {
+			// 展开的map与当前层合并，nil时忽略
+			it := g.newVar("it")
+			k := g.newVar("k")
+			g.printf("for %s, %s := range %s {\n", k, it, val)
+			g.emitElemToMap("dest["+g.mapKeyString(k, t.key)+"]", it, t.elem)
+			g.printf("}\n")
+			return
+		}
+		if omitempty {
+			g.printf("if len(%s) > 0 {\n", val)
+			g.emitContainerToMap("dest["+key+"]", val, t)
+			g.printf("}\n")
+			return
+		}
+		g.emitElemToMap("dest["+key+"]", val, t)
+	default:
+		// 无法静态展开的类型(其他包中的类型、数组等)仍通过反射转换
+		g.printf("{\n")
+		v := g.newVar("val")
+		g.printf("%s, err := dcopy.ValueToMapValue(%s, %sOpts...)\n", v, val, g.pre)
+		g.printf("if err != nil {\nreturn err\n}\n")
+		g.printf("dest[%s] = %s\n", key, v)
+		g.printf("}\n")
+	}
+}
+
+// emitElemToMap 生成slice/map元素的转换，规则同 instanceSliceToArr：基础类型的元素保持原类型
+// dst为可赋值的表达式
+func (g *generator) emitElemToMap(dst, val string, t *typeInfo) {
+	switch t.kind {
+	case kindPtr:
+		g.printf("if %s == nil {\n%s = nil\n} else {\n", val, dst)
+		g.emitElemToMap(dst, deref(val), t.elem)
+		g.printf("}\n")
+	case kindBasic, kindInterface:
+		g.printf("%s = %s\n", dst, val)
+	case kindStruct:
+		sub := g.newVar("sub")
+		g.printf("%s := map[string]interface{}{}\n", sub)
+		g.printf("%s = %s\n", dst, sub)
+		g.printf("if err := %s(%s, %s); err != nil {\nreturn err\n}\n", g.toMapFunc(t.named), sub, addr(val))
+	case kindSlice, kindMap:
+		g.printf("if %s == nil {\n%s = %s\n} else {\n", val, dst, g.nilValue(t))
+		g.emitContainerToMap(dst, val, t)
+		g.printf("}\n")
+	default:
+		v := g.newVar("val")
+		g.printf("%s, err := dcopy.ElemToMapValue(%s, %sOpts...)\n", v, val, g.pre)
+		g.printf("if err != nil {\nreturn err\n}\n")
+		g.printf("%s = %s\n", dst, v)
+	}
+}
+
+// emitContainerToMap 非nil的slice转换成 []interface{}，map转换成 map[string]interface{}
+func (g *generator) emitContainerToMap(dst, val string, t *typeInfo) {
+	out := g.newVar("out")
+	it := g.newVar("it")
+	if t.kind == kindSlice {
+		i := g.newVar("i")
+		g.printf("%s := make([]interface{}, len(%s))\n", out, val)
+		g.printf("for %s, %s := range %s {\n", i, it, val)
+		g.emitElemToMap(out+"["+i+"]", it, t.elem)
+	} else {
+		k := g.newVar("k")
+		g.printf("%s := make(map[string]interface{}, len(%s))\n", out, val)
+		g.printf("for %s, %s := range %s {\n", k, it, val)
+		g.emitElemToMap(out+"["+g.mapKeyString(k, t.key)+"]", it, t.elem)
+	}
+	g.printf("}\n")
+	g.printf("%s = %s\n", dst, out)
+}
+
+// nilValue nil的map/slice按 -nilasempty 输出
+func (g *generator) nilValue(t *typeInfo) string {
+	if !g.cfg.nilAsEmpty {
+		return "nil"
+	}
+	if t.kind == kindSlice {
+		return "[]interface{}{}"
+	}
+	return "map[string]interface{}{}"
+}
+
+// mapKeyString map的key转换成字符串，同 interface2String
+func (g *generator) mapKeyString(k string, t *typeInfo) string {
+	if t.kind == kindBasic && t.basic == "string" {
+		return convert("string", t.expr, k)
+	}
+	return "dcopy.ToString(" + k + ")"
+}
+
+// basicZero 返回非0判断表达式和 getBasicValue 对应的转换表达式
+func basicZero(t *typeInfo, val string) (notZero, conv string) {
+	switch t.basic {
+	case "bool":
+		return val, convert("bool", t.expr, val)
+	case "string":
+		return val + ` != ""`, convert("string", t.expr, val)
+	case "int", "int8", "int16", "int32", "int64":
+		return val + " != 0", convert("int64", t.expr, val)
+	case "uint", "uint8", "uint16", "uint32", "uint64", "uintptr":
+		return val + " != 0", convert("uint64", t.expr, val)
+	default:
+		return val + " != 0", convert("float64", t.expr, val)
+	}
+}
+
+// ---------------------------------------------------------------- InstanceFromMap
+
+func (g *generator) genFromMap(fn, name string) error {
+	st, err := g.pkg.lookupStruct(name)
+	if err != nil {
+		return err
+	}
+	g.printf("func %s(dest *%s, from map[string]interface{}) error {\n", fn, name)
+	for _, field := range st.fields {
+		if !field.exported {
+			continue
+		}
+		key, _, ignore := dcopy.FieldTagName(field.structField(), g.opts...)
+		if ignore {
+			continue
+		}
+		target := "dest." + field.name
+		if g.squash(field) || g.inlineMap(field) {
+			// 展开的字段从同一层map中读取
+			g.emitFromAnonymous(target, field.tp)
+			continue
+		}
+		g.printf("if v, ok := from[%q]; ok && v != nil {\n", key)
+		g.emitFromMap(target, field.tp, "v")
+		g.printf("}\n")
+	}
+	g.printf("return nil\n}\n\n")
+	return nil
+}
+
+// squash 结构体字段是否展开到当前层，规则同 dcopy.FieldSquash
+func (g *generator) squash(field fieldInfo) bool {
+	t := field.tp
+	if t.kind == kindPtr {
+		t = t.elem
+	}
+	return t.kind == kindStruct && dcopy.FieldSquash(field.structField(), g.opts...)
+}
+
+// inlineMap map类型的字段是否与当前层合并，规则同 structFields
+func (g *generator) inlineMap(field fieldInfo) bool {
+	return field.tp.kind == kindMap && field.tp.key.kind == kindBasic && dcopy.FieldSquash(field.structField(), g.opts...)
+}
+
+func (g *generator) emitFromAnonymous(target string, t *typeInfo) {
+	switch t.kind {
+	case kindStruct:
+		g.printf("if err := %s(%s, from); err != nil {\nreturn err\n}\n", g.fromMapFunc(t.named), addr(target))
+	case kindPtr:
+		p := g.newVar("p")
+		g.printf("%s := new(%s)\n", p, g.useType(t.elem))
+		g.emitFromAnonymous(deref(p), t.elem)
+		g.printf("%s = %s\n", target, p)
+	case kindMap:
+		g.emitMapFromMap(target, t, "from")
+	default:
+		g.printf("if err := dcopy.InstanceFromMap(%s, from, %sOpts...); err != nil {\nreturn err\n}\n", addr(target), g.pre)
+	}
+}
+
+func (g *generator) emitFromMap(target string, t *typeInfo, src string) {
+	switch t.kind {
+	case kindBasic:
+		fn, ret := basicConv(t.basic)
+		g.printf("%s = %s\n", target, convert(g.useType(t), ret, fn+"("+src+")"))
+	case kindTime:
+		tm := g.newVar("tm")
+		g.printf("if %s, ok := dcopy.ToTime(%s, %sOpts...); ok {\n%s = %s\n}\n", tm, src, g.pre, target, tm)
+	case kindStruct:
+		m := g.newVar("m")
+		g.printf("if %s, ok := %s.(map[string]interface{}); ok {\n", m, src)
+		g.printf("if err := %s(%s, %s); err != nil {\nreturn err\n}\n", g.fromMapFunc(t.named), addr(target), m)
+		g.printf("}\n")
+	case kindPtr:
+		p := g.newVar("p")
+		g.printf("%s := new(%s)\n", p, g.useType(t.elem))
+		g.emitFromMap(deref(p), t.elem, src)
+		g.printf("%s = %s\n", target, p)
+	case kindInterface:
+		x := g.newVar("x")
+		g.printf("if %s, ok := %s.(%s); ok {\n%s = %s\n}\n", x, src, g.useType(t), target, x)
+	case kindSlice:
+		s := g.newVar("s")
+		out := g.newVar("out")
+		i := g.newVar("i")
+		it := g.newVar("it")
+		g.printf("if %s, ok := %s.([]interface{}); ok {\n", s, src)
+		g.printf("%s := make(%s, len(%s))\n", out, g.useType(t), s)
+		g.printf("for %s, %s := range %s {\n", i, it, s)
+		g.emitFromMap(out+"["+i+"]", t.elem, it)
+		g.printf("}\n")
+		g.printf("%s = %s\n}\n", target, out)
+	case kindMap:
+		if t.key.kind != kindBasic {
+			g.printf("if err := dcopy.InstanceFromMap(%s, %s, %sOpts...); err != nil {\nreturn err\n}\n", addr(target), src, g.pre)
+			return
+		}
+		m := g.newVar("m")
+		g.printf("if %s, ok := %s.(map[string]interface{}); ok {\n", m, src)
+		g.emitMapFromMap(target, t, m)
+		g.printf("}\n")
+	default:
+		// 无法静态展开的类型(其他包中的类型、数组等)仍通过反射转换
+		g.printf("if err := dcopy.InstanceFromMap(%s, %s, %sOpts...); err != nil {\nreturn err\n}\n", addr(target), src, g.pre)
+	}
+}
+
+// emitMapFromMap 从 map[string]interface{} 类型的m生成map，key按基础类型转换
+func (g *generator) emitMapFromMap(target string, t *typeInfo, m string) {
+	out := g.newVar("out")
+	k := g.newVar("k")
+	it := g.newVar("it")
+	e := g.newVar("e")
+	g.printf("%s := make(%s, len(%s))\n", out, g.useType(t), m)
+	g.printf("for %s, %s := range %s {\n", k, it, m)
+	g.printf("var %s %s\n", e, g.useType(t.elem))
+	g.emitFromMap(e, t.elem, it)
+	key := convert(g.useType(t.key), "string", k)
+	if t.key.basic != "string" {
+		fn, ret := basicConv(t.key.basic)
+		key = convert(g.useType(t.key), ret, fn+"("+k+")")
+	}
+	g.printf("%s[%s] = %s\n", out, key, e)
+	g.printf("}\n")
+	g.printf("%s = %s\n", target, out)
+}
+
+// basicConv 返回转换函数及其返回类型
+func basicConv(basic string) (fn, ret string) {
+	switch basic {
+	case "bool":
+		return "dcopy.ToBool", "bool"
+	case "string":
+		return "dcopy.ToString", "string"
+	case "int", "int8", "int16", "int32", "int64":
+		return "dcopy.ToInt64", "int64"
+	case "uint", "uint8", "uint16", "uint32", "uint64", "uintptr":
+		return "dcopy.ToUint64", "uint64"
+	default:
+		return "dcopy.ToFloat64", "float64"
+	}
+}
+
+// convert 类型不同时才生成类型转换
+func convert(dest, from, expr string) string {
+	if dest == from {
+		return expr
+	}
+	return dest + "(" + expr + ")"
+}
+
+func deref(expr string) string {
+	return "(*" + expr + ")"
+}
+
+func addr(expr string) string {
+	if strings.HasPrefix(expr, "(*") && strings.HasSuffix(expr, ")") {
+		return expr[2 : len(expr)-1]
+	}
+	return "&" + expr
+}
+
+// ---------------------------------------------------------------- StructCopy
+
+type sourceField struct {
+	access string
+	tp     *typeInfo
+	ignore bool // tag中指定了忽略
+}
+
+// sourceFields 同 reflect.Value.FieldByName，包括匿名组合中提升的字段，浅层优先
+func (g *generator) sourceFields(name, access string, out map[string]sourceField, seen map[string]bool) error {
+	if seen[name] {
+		return nil
+	}
+	seen[name] = true
+	st, err := g.pkg.lookupStruct(name)
+	if err != nil {
+		return err
+	}
+	for _, field := range st.fields {
+		if _, ok := out[field.name]; !ok {
+			_, _, ignore := dcopy.FieldTagName(field.structField())
+			out[field.name] = sourceField{access: access + "." + field.name, tp: field.tp, ignore: ignore}
+		}
+	}
+	for _, field := range st.fields {
+		if field.anonymous && field.tp.kind == kindStruct {
+			sub := map[string]sourceField{}
+			if err := g.sourceFields(field.tp.named, access+"."+field.name, sub, seen); err != nil {
+				return err
+			}
+			for k, v := range sub {
+				if _, ok := out[k]; !ok {
+					out[k] = v
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (g *generator) genCopy(fn, dest, from string) error {
+	st, err := g.pkg.lookupStruct(dest)
+	if err != nil {
+		return err
+	}
+	fromFields := map[string]sourceField{}
+	if err := g.sourceFields(from, "from", fromFields, map[string]bool{}); err != nil {
+		return err
+	}
+	g.printf("func %s(dest *%s, from *%s) error {\n", fn, dest, from)
+	for _, field := range st.fields {
+		// 两侧的忽略规则同 StructCopy 的默认参数
+		if _, _, ignore := dcopy.FieldTagName(field.structField()); !field.exported || ignore {
+			continue
+		}
+		src, ok := fromFields[field.name]
+		if !ok || src.ignore || !copyable(field.tp, src.tp) {
+			continue
+		}
+		g.emitCopy("dest."+field.name, field.tp, src.access, src.tp)
+	}
+	g.printf("return nil\n}\n\n")
+	return nil
+}
+
+// copyable 同 isFieldTypeMatch，指针取值后比较
+func copyable(dest, from *typeInfo) bool {
+	for dest.kind == kindPtr {
+		dest = dest.elem
+	}
+	for from.kind == kindPtr {
+		from = from.elem
+	}
+	switch dest.kind {
+	case kindBasic:
+		return from.kind == kindBasic && basicFamily(dest.basic) == basicFamily(from.basic)
+	case kindSlice:
+		return from.kind == kindSlice && dest.elem.expr == from.elem.expr
+	case kindMap:
+		return from.kind == kindMap && dest.key.expr == from.key.expr && dest.elem.expr == from.elem.expr
+	case kindStruct:
+		return from.kind == kindStruct
+	default:
+		// time.Time 及其他相同类型直接赋值
+		return dest.expr == from.expr
+	}
+}
+
+func basicFamily(basic string) string {
+	switch basic {
+	case "int", "int8", "int16", "int32", "int64":
+		return "int"
+	case "uint", "uint8", "uint16", "uint32", "uint64", "uintptr":
+		return "uint"
+	case "float32", "float64":
+		return "float"
+	}
+	return basic
+}
+
+func (g *generator) emitCopy(target string, dt *typeInfo, src string, st *typeInfo) {
+	if st.kind == kindPtr {
+		g.printf("if %s != nil {\n", src)
+		g.emitCopy(target, dt, deref(src), st.elem)
+		g.printf("}\n")
+		return
+	}
+	if dt.kind == kindPtr {
+		g.printf("if %s == nil {\n%s = new(%s)\n}\n", target, target, g.useType(dt.elem))
+		g.emitCopy(deref(target), dt.elem, src, st)
+		return
+	}
+	switch dt.kind {
+	case kindBasic:
+		g.printf("%s = %s\n", target, convert(g.useType(dt), st.expr, src))
+	case kindSlice:
+		// nil保持nil，元素逐个深度拷贝
+		out := g.newVar("out")
+		i := g.newVar("i")
+		g.printf("if %s == nil {\n%s = nil\n} else {\n", src, target)
+		g.printf("%s := make(%s, len(%s))\n", out, g.useType(dt), src)
+		g.printf("for %s := range %s {\n", i, src)
+		g.emitCopy(out+"["+i+"]", dt.elem, src+"["+i+"]", st.elem)
+		g.printf("}\n")
+		g.printf("%s = %s\n}\n", target, out)
+	case kindMap:
+		out := g.newVar("out")
+		k := g.newVar("k")
+		it := g.newVar("it")
+		e := g.newVar("e")
+		g.printf("if %s == nil {\n%s = nil\n} else {\n", src, target)
+		g.printf("%s := make(%s, len(%s))\n", out, g.useType(dt), src)
+		g.printf("for %s, %s := range %s {\n", k, it, src)
+		g.printf("var %s %s\n", e, g.useType(dt.elem))
+		g.emitCopy(e, dt.elem, it, st.elem)
+		g.printf("%s[%s] = %s\n", out, k, e)
+		g.printf("}\n")
+		g.printf("%s = %s\n}\n", target, out)
+	case kindStruct:
+		g.printf("if err := %s(%s, %s); err != nil {\nreturn err\n}\n", g.copyFunc(dt.named, st.named), addr(target), addr(src))
+	case kindInterface:
+		g.printf("%s = dcopy.Clone(%s)\n", target, src)
+	default:
+		g.printf("%s = %s\n", target, src)
+	}
+}
@@ -0,0 +1,122 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: gen_test.go
+ * @time: 2026/10/19 14:10
+ * @project: deepcopy
+ */
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config
+		want    []string
+		notWant []string
+		wantErr bool
+	}{
+		{
+			name: "TestGenerate_json",
+			cfg: config{
+				types:     []string{"User"},
+				froms:     []string{"UserDTO"},
+				fieldType: "idle",
+				timeFmt:   "2006-01-02 15:04:05",
+				timeType:  "string",
+			},
+			want: []string{
+				"func UserToMap(from *User) (map[string]interface{}, error)",
+				"func UserFromMap(dest *User, from map[string]interface{}) error",
+				"func UserFromUserDTO(dest *User, from *UserDTO) error",
+				`dest["name"] = from.Name`,
+				`if from.Age != 0 {`,
+				`dest["status"] = int64(from.Status)`,
+				`dest.Status = Status(dcopy.ToInt64(v))`,
+				`dest.Age = int(from.Age)`,
+				`if err := dcopyGenUserBaseToMap(dest, &from.Base); err != nil {`,
+				`dest["home"] = nil`,
+			},
+			notWant: []string{
+				`"secret"`,
+				`internal`,
+			},
+		},
+		{
+			name: "TestGenerate_origin",
+			cfg: config{
+				types:     []string{"User"},
+				fieldType: "origin",
+				timeFmt:   "2006-01-02",
+				timeType:  "int64",
+			},
+			want: []string{
+				`dest["Name"] = from.Name`,
+				`dcopy.WithTimeValType(dcopy.TimeValType_Int64)`,
+			},
+			notWant: []string{
+				"func UserFromUserDTO",
+			},
+		},
+		{
+			name: "TestGenerate_notFound",
+			cfg: config{
+				types:     []string{"NotExist"},
+				fieldType: "idle",
+				timeType:  "string",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := generate("testdata/sample", tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("generate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			src := string(got)
+			for _, it := range tt.want {
+				if !strings.Contains(src, it) {
+					t.Errorf("generate() missing %q", it)
+				}
+			}
+			for _, it := range tt.notWant {
+				if strings.Contains(src, it) {
+					t.Errorf("generate() unexpected %q", it)
+				}
+			}
+		})
+	}
+}
+
+// TestGenerateFixture 生成的代码与反射实现的比对在 internal/fixture 中，这里保证提交的文件是最新的
+func TestGenerateFixture(t *testing.T) {
+	got, err := generate("internal/fixture", config{
+		types:     []string{"Order"},
+		froms:     []string{"OrderDTO"},
+		fieldType: "idle",
+		timeFmt:   "2006-01-02 15:04:05",
+		timeType:  "string",
+	})
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+	want, err := os.ReadFile("internal/fixture/order_dcopy.go")
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("internal/fixture/order_dcopy.go is stale, run go generate ./cmd/dcopy-gen/internal/fixture")
+	}
+}
@@ -0,0 +1,62 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: fixture.go
+ * @time: 2026/10/21 10:20
+ * @project: deepcopy
+ */
+
+// Package fixture 用于校验 dcopy-gen 生成的代码与反射实现的结果一致
+package fixture
+
+import "time"
+
+//go:generate go run ../.. -type=Order -from=OrderDTO
+
+type Status int
+
+type Labels map[string]string
+
+type Item struct {
+	Sku   string  `json:"sku"`
+	Qty   int     `json:"qty"`
+	Price float64 `json:"price"`
+}
+
+type Order struct {
+	ID      int64                  `json:"id"`
+	Status  Status                 `json:"status"`
+	Items   []Item                 `json:"items"`
+	Refs    []*Item                `json:"refs"`
+	Tags    []string               `json:"tags"`
+	Matrix  [][]string             `json:"matrix"`
+	Counts  map[string]int         `json:"counts"`
+	ByKey   map[string]*Item       `json:"byKey"`
+	Labels  Labels                 `json:"labels"`
+	Extra   interface{}            `json:"extra"`
+	Meta    map[string]interface{} `json:"meta"`
+	Created time.Time              `json:"created"`
+	Note    *string                `json:"note"`
+	Secret  string                 `json:"-"`
+}
+
+type OrderDTO struct {
+	ID      int32
+	Status  int
+	Items   []Item
+	Refs    []*Item
+	Tags    []string
+	Matrix  [][]string
+	Counts  map[string]int
+	ByKey   map[string]*Item
+	Labels  Labels
+	Extra   interface{}
+	Meta    map[string]interface{}
+	Created time.Time
+	Note    *string
+	Secret  string
+}
@@ -0,0 +1,154 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: fixture_test.go
+ * @time: 2026/10/21 10:20
+ * @project: deepcopy
+ */
+
+package fixture
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/generalzgd/deepcopy/dcopy"
+)
+
+func newOrderDTO() *OrderDTO {
+	note := "fragile"
+	return &OrderDTO{
+		ID:      7,
+		Status:  2,
+		Items:   []Item{{Sku: "a", Qty: 1, Price: 1.5}, {Sku: "b", Qty: 2}},
+		Refs:    []*Item{nil, {Sku: "c", Qty: 3}},
+		Tags:    []string{"x", "y"},
+		Matrix:  [][]string{{"1", "2"}, {}},
+		Counts:  map[string]int{"a": 1, "b": 2},
+		ByKey:   map[string]*Item{"a": {Sku: "a"}, "nil": nil},
+		Labels:  Labels{"env": "prod"},
+		Extra:   Status(3),
+		Meta:    map[string]interface{}{"n": 1, "list": []interface{}{"a"}, "obj": map[string]interface{}{"k": "v"}},
+		Created: time.Date(2026, 10, 21, 10, 20, 0, 0, time.Local),
+		Note:    &note,
+		Secret:  "secret",
+	}
+}
+
+func newOrders() map[string]*Order {
+	full := &Order{}
+	if err := dcopy.StructCopy(full, newOrderDTO()); err != nil {
+		panic(err)
+	}
+	return map[string]*Order{
+		"zero":  {},
+		"full":  full,
+		"empty": {Items: []Item{}, Tags: []string{}, Counts: map[string]int{}, Extra: "s"},
+	}
+}
+
+func TestOrderFromOrderDTO(t *testing.T) {
+	tests := []struct {
+		name string
+		from *OrderDTO
+		dest *Order
+	}{
+		{name: "TestOrderFromOrderDTO_full", from: newOrderDTO(), dest: &Order{}},
+		{name: "TestOrderFromOrderDTO_zero", from: &OrderDTO{}, dest: &Order{}},
+		{
+			name: "TestOrderFromOrderDTO_nilKeepsNil",
+			from: &OrderDTO{},
+			dest: &Order{Items: []Item{{Sku: "old"}}, Counts: map[string]int{"old": 1}, Secret: "keep"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := *tt.dest
+			if err := dcopy.StructCopy(&want, tt.from); err != nil {
+				t.Fatalf("StructCopy() error = %v", err)
+			}
+			got := *tt.dest
+			if err := OrderFromOrderDTO(&got, tt.from); err != nil {
+				t.Fatalf("OrderFromOrderDTO() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("OrderFromOrderDTO() = %+v, StructCopy() = %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestOrderFromOrderDTO_deep(t *testing.T) {
+	from := newOrderDTO()
+	var got Order
+	if err := OrderFromOrderDTO(&got, from); err != nil {
+		t.Fatalf("OrderFromOrderDTO() error = %v", err)
+	}
+	from.Items[0].Sku = "changed"
+	from.Refs[1].Sku = "changed"
+	from.Matrix[0][0] = "changed"
+	from.ByKey["a"].Sku = "changed"
+	from.Meta["list"].([]interface{})[0] = "changed"
+	if got.Items[0].Sku != "a" || got.Refs[1].Sku != "c" || got.Matrix[0][0] != "1" ||
+		got.ByKey["a"].Sku != "a" || got.Meta["list"].([]interface{})[0] != "a" {
+		t.Errorf("OrderFromOrderDTO() shares memory with source: %+v", got)
+	}
+}
+
+func TestOrderToMap(t *testing.T) {
+	orders := newOrders()
+	// InstanceFromMap 无法解析nil的嵌套slice，只校验 ToMap
+	orders["nilElem"] = &Order{Matrix: [][]string{nil, {"a"}}, Refs: []*Item{nil}}
+	for name, order := range orders {
+		t.Run("TestOrderToMap_"+name, func(t *testing.T) {
+			want, err := dcopy.InstanceToMap(order, dcopyGenOrderOpts...)
+			if err != nil {
+				t.Fatalf("InstanceToMap() error = %v", err)
+			}
+			got, err := OrderToMap(order)
+			if err != nil {
+				t.Fatalf("OrderToMap() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("OrderToMap() = %#v, InstanceToMap() = %#v", got, want)
+			}
+		})
+	}
+}
+
+func TestOrderFromMap(t *testing.T) {
+	for name, order := range newOrders() {
+		mp, err := dcopy.InstanceToMap(order, dcopyGenOrderOpts...)
+		if err != nil {
+			t.Fatalf("InstanceToMap() error = %v", err)
+		}
+		data, err := json.Marshal(mp)
+		if err != nil {
+			t.Fatalf("json.Marshal() error = %v", err)
+		}
+		var decoded map[string]interface{}
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v", err)
+		}
+		for src, from := range map[string]map[string]interface{}{"map": mp, "json": decoded} {
+			t.Run("TestOrderFromMap_"+name+"_"+src, func(t *testing.T) {
+				var want, got Order
+				if err := dcopy.InstanceFromMap(&want, from, dcopyGenOrderOpts...); err != nil {
+					t.Fatalf("InstanceFromMap() error = %v", err)
+				}
+				if err := OrderFromMap(&got, from); err != nil {
+					t.Fatalf("OrderFromMap() error = %v", err)
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("OrderFromMap() = %+v, InstanceFromMap() = %+v", got, want)
+				}
+			})
+		}
+	}
+}
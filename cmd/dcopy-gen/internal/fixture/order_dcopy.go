@@ -0,0 +1,420 @@
+// Code generated by dcopy-gen; DO NOT EDIT.
+
+package fixture
+
+import (
+	"github.com/generalzgd/deepcopy/dcopy"
+)
+
+var dcopyGenOrderOpts = []dcopy.CopyOption{
+	dcopy.WithFieldType(dcopy.FieldType_Idle),
+	dcopy.WithOmitempty(false),
+	dcopy.WithTimeFormatStr("2006-01-02 15:04:05"),
+	dcopy.WithTimeValType(dcopy.TimeValType_String),
+}
+
+// OrderToMap 等价于 dcopy.InstanceToMap(from, dcopyGenOrderOpts...)
+func OrderToMap(from *Order) (map[string]interface{}, error) {
+	out := map[string]interface{}{}
+	if err := dcopyGenOrderOrderToMap(out, from); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrderFromMap 等价于 dcopy.InstanceFromMap(dest, from, dcopyGenOrderOpts...)
+func OrderFromMap(dest *Order, from map[string]interface{}) error {
+	return dcopyGenOrderOrderFromMap(dest, from)
+}
+
+// OrderFromOrderDTO 等价于 dcopy.StructCopy(dest, from)
+func OrderFromOrderDTO(dest *Order, from *OrderDTO) error {
+	return dcopyGenOrderCopyOrderFromOrderDTO(dest, from)
+}
+
+func dcopyGenOrderOrderToMap(dest map[string]interface{}, from *Order) error {
+	dest["id"] = from.ID
+	dest["status"] = int64(from.Status)
+	if from.Items == nil {
+		dest["items"] = nil
+	} else {
+		out1 := make([]interface{}, len(from.Items))
+		for i3, it2 := range from.Items {
+			sub4 := map[string]interface{}{}
+			out1[i3] = sub4
+			if err := dcopyGenOrderItemToMap(sub4, &it2); err != nil {
+				return err
+			}
+		}
+		dest["items"] = out1
+	}
+	if from.Refs == nil {
+		dest["refs"] = nil
+	} else {
+		out5 := make([]interface{}, len(from.Refs))
+		for i7, it6 := range from.Refs {
+			if it6 == nil {
+				out5[i7] = nil
+			} else {
+				sub8 := map[string]interface{}{}
+				out5[i7] = sub8
+				if err := dcopyGenOrderItemToMap(sub8, it6); err != nil {
+					return err
+				}
+			}
+		}
+		dest["refs"] = out5
+	}
+	if from.Tags == nil {
+		dest["tags"] = nil
+	} else {
+		out9 := make([]interface{}, len(from.Tags))
+		for i11, it10 := range from.Tags {
+			out9[i11] = it10
+		}
+		dest["tags"] = out9
+	}
+	if from.Matrix == nil {
+		dest["matrix"] = nil
+	} else {
+		out12 := make([]interface{}, len(from.Matrix))
+		for i14, it13 := range from.Matrix {
+			if it13 == nil {
+				out12[i14] = nil
+			} else {
+				out15 := make([]interface{}, len(it13))
+				for i17, it16 := range it13 {
+					out15[i17] = it16
+				}
+				out12[i14] = out15
+			}
+		}
+		dest["matrix"] = out12
+	}
+	if from.Counts == nil {
+		dest["counts"] = nil
+	} else {
+		out18 := make(map[string]interface{}, len(from.Counts))
+		for k20, it19 := range from.Counts {
+			out18[k20] = it19
+		}
+		dest["counts"] = out18
+	}
+	if from.ByKey == nil {
+		dest["byKey"] = nil
+	} else {
+		out21 := make(map[string]interface{}, len(from.ByKey))
+		for k23, it22 := range from.ByKey {
+			if it22 == nil {
+				out21[k23] = nil
+			} else {
+				sub24 := map[string]interface{}{}
+				out21[k23] = sub24
+				if err := dcopyGenOrderItemToMap(sub24, it22); err != nil {
+					return err
+				}
+			}
+		}
+		dest["byKey"] = out21
+	}
+	if from.Labels == nil {
+		dest["labels"] = nil
+	} else {
+		out25 := make(map[string]interface{}, len(from.Labels))
+		for k27, it26 := range from.Labels {
+			out25[k27] = it26
+		}
+		dest["labels"] = out25
+	}
+	dest["extra"] = dcopy.BasicValue(from.Extra)
+	if from.Meta == nil {
+		dest["meta"] = nil
+	} else {
+		out28 := make(map[string]interface{}, len(from.Meta))
+		for k30, it29 := range from.Meta {
+			out28[k30] = it29
+		}
+		dest["meta"] = out28
+	}
+	dest["created"] = dcopy.FromTime(from.Created, dcopyGenOrderOpts...)
+	if from.Note != nil {
+		dest["note"] = (*from.Note)
+	} else {
+		dest["note"] = nil
+	}
+	return nil
+}
+
+func dcopyGenOrderOrderFromMap(dest *Order, from map[string]interface{}) error {
+	if v, ok := from["id"]; ok && v != nil {
+		dest.ID = dcopy.ToInt64(v)
+	}
+	if v, ok := from["status"]; ok && v != nil {
+		dest.Status = Status(dcopy.ToInt64(v))
+	}
+	if v, ok := from["items"]; ok && v != nil {
+		if s31, ok := v.([]interface{}); ok {
+			out32 := make([]Item, len(s31))
+			for i33, it34 := range s31 {
+				if m35, ok := it34.(map[string]interface{}); ok {
+					if err := dcopyGenOrderItemFromMap(&out32[i33], m35); err != nil {
+						return err
+					}
+				}
+			}
+			dest.Items = out32
+		}
+	}
+	if v, ok := from["refs"]; ok && v != nil {
+		if s36, ok := v.([]interface{}); ok {
+			out37 := make([]*Item, len(s36))
+			for i38, it39 := range s36 {
+				p40 := new(Item)
+				if m41, ok := it39.(map[string]interface{}); ok {
+					if err := dcopyGenOrderItemFromMap(p40, m41); err != nil {
+						return err
+					}
+				}
+				out37[i38] = p40
+			}
+			dest.Refs = out37
+		}
+	}
+	if v, ok := from["tags"]; ok && v != nil {
+		if s42, ok := v.([]interface{}); ok {
+			out43 := make([]string, len(s42))
+			for i44, it45 := range s42 {
+				out43[i44] = dcopy.ToString(it45)
+			}
+			dest.Tags = out43
+		}
+	}
+	if v, ok := from["matrix"]; ok && v != nil {
+		if s46, ok := v.([]interface{}); ok {
+			out47 := make([][]string, len(s46))
+			for i48, it49 := range s46 {
+				if s50, ok := it49.([]interface{}); ok {
+					out51 := make([]string, len(s50))
+					for i52, it53 := range s50 {
+						out51[i52] = dcopy.ToString(it53)
+					}
+					out47[i48] = out51
+				}
+			}
+			dest.Matrix = out47
+		}
+	}
+	if v, ok := from["counts"]; ok && v != nil {
+		if m54, ok := v.(map[string]interface{}); ok {
+			out55 := make(map[string]int, len(m54))
+			for k56, it57 := range m54 {
+				var e58 int
+				e58 = int(dcopy.ToInt64(it57))
+				out55[k56] = e58
+			}
+			dest.Counts = out55
+		}
+	}
+	if v, ok := from["byKey"]; ok && v != nil {
+		if m59, ok := v.(map[string]interface{}); ok {
+			out60 := make(map[string]*Item, len(m59))
+			for k61, it62 := range m59 {
+				var e63 *Item
+				p64 := new(Item)
+				if m65, ok := it62.(map[string]interface{}); ok {
+					if err := dcopyGenOrderItemFromMap(p64, m65); err != nil {
+						return err
+					}
+				}
+				e63 = p64
+				out60[k61] = e63
+			}
+			dest.ByKey = out60
+		}
+	}
+	if v, ok := from["labels"]; ok && v != nil {
+		if m66, ok := v.(map[string]interface{}); ok {
+			out67 := make(Labels, len(m66))
+			for k68, it69 := range m66 {
+				var e70 string
+				e70 = dcopy.ToString(it69)
+				out67[k68] = e70
+			}
+			dest.Labels = out67
+		}
+	}
+	if v, ok := from["extra"]; ok && v != nil {
+		if x71, ok := v.(interface{}); ok {
+			dest.Extra = x71
+		}
+	}
+	if v, ok := from["meta"]; ok && v != nil {
+		if m72, ok := v.(map[string]interface{}); ok {
+			out73 := make(map[string]interface{}, len(m72))
+			for k74, it75 := range m72 {
+				var e76 interface{}
+				if x77, ok := it75.(interface{}); ok {
+					e76 = x77
+				}
+				out73[k74] = e76
+			}
+			dest.Meta = out73
+		}
+	}
+	if v, ok := from["created"]; ok && v != nil {
+		if tm78, ok := dcopy.ToTime(v, dcopyGenOrderOpts...); ok {
+			dest.Created = tm78
+		}
+	}
+	if v, ok := from["note"]; ok && v != nil {
+		p79 := new(string)
+		(*p79) = dcopy.ToString(v)
+		dest.Note = p79
+	}
+	return nil
+}
+
+func dcopyGenOrderCopyOrderFromOrderDTO(dest *Order, from *OrderDTO) error {
+	dest.ID = int64(from.ID)
+	dest.Status = Status(from.Status)
+	if from.Items == nil {
+		dest.Items = nil
+	} else {
+		out80 := make([]Item, len(from.Items))
+		for i81 := range from.Items {
+			if err := dcopyGenOrderCopyItemFromItem(&out80[i81], &from.Items[i81]); err != nil {
+				return err
+			}
+		}
+		dest.Items = out80
+	}
+	if from.Refs == nil {
+		dest.Refs = nil
+	} else {
+		out82 := make([]*Item, len(from.Refs))
+		for i83 := range from.Refs {
+			if from.Refs[i83] != nil {
+				if out82[i83] == nil {
+					out82[i83] = new(Item)
+				}
+				if err := dcopyGenOrderCopyItemFromItem(out82[i83], from.Refs[i83]); err != nil {
+					return err
+				}
+			}
+		}
+		dest.Refs = out82
+	}
+	if from.Tags == nil {
+		dest.Tags = nil
+	} else {
+		out84 := make([]string, len(from.Tags))
+		for i85 := range from.Tags {
+			out84[i85] = from.Tags[i85]
+		}
+		dest.Tags = out84
+	}
+	if from.Matrix == nil {
+		dest.Matrix = nil
+	} else {
+		out86 := make([][]string, len(from.Matrix))
+		for i87 := range from.Matrix {
+			if from.Matrix[i87] == nil {
+				out86[i87] = nil
+			} else {
+				out88 := make([]string, len(from.Matrix[i87]))
+				for i89 := range from.Matrix[i87] {
+					out88[i89] = from.Matrix[i87][i89]
+				}
+				out86[i87] = out88
+			}
+		}
+		dest.Matrix = out86
+	}
+	if from.Counts == nil {
+		dest.Counts = nil
+	} else {
+		out90 := make(map[string]int, len(from.Counts))
+		for k91, it92 := range from.Counts {
+			var e93 int
+			e93 = it92
+			out90[k91] = e93
+		}
+		dest.Counts = out90
+	}
+	if from.ByKey == nil {
+		dest.ByKey = nil
+	} else {
+		out94 := make(map[string]*Item, len(from.ByKey))
+		for k95, it96 := range from.ByKey {
+			var e97 *Item
+			if it96 != nil {
+				if e97 == nil {
+					e97 = new(Item)
+				}
+				if err := dcopyGenOrderCopyItemFromItem(e97, it96); err != nil {
+					return err
+				}
+			}
+			out94[k95] = e97
+		}
+		dest.ByKey = out94
+	}
+	if from.Labels == nil {
+		dest.Labels = nil
+	} else {
+		out98 := make(Labels, len(from.Labels))
+		for k99, it100 := range from.Labels {
+			var e101 string
+			e101 = it100
+			out98[k99] = e101
+		}
+		dest.Labels = out98
+	}
+	dest.Extra = dcopy.Clone(from.Extra)
+	if from.Meta == nil {
+		dest.Meta = nil
+	} else {
+		out102 := make(map[string]interface{}, len(from.Meta))
+		for k103, it104 := range from.Meta {
+			var e105 interface{}
+			e105 = dcopy.Clone(it104)
+			out102[k103] = e105
+		}
+		dest.Meta = out102
+	}
+	dest.Created = from.Created
+	if from.Note != nil {
+		if dest.Note == nil {
+			dest.Note = new(string)
+		}
+		(*dest.Note) = (*from.Note)
+	}
+	return nil
+}
+
+func dcopyGenOrderItemToMap(dest map[string]interface{}, from *Item) error {
+	dest["sku"] = from.Sku
+	dest["qty"] = int64(from.Qty)
+	dest["price"] = from.Price
+	return nil
+}
+
+func dcopyGenOrderItemFromMap(dest *Item, from map[string]interface{}) error {
+	if v, ok := from["sku"]; ok && v != nil {
+		dest.Sku = dcopy.ToString(v)
+	}
+	if v, ok := from["qty"]; ok && v != nil {
+		dest.Qty = int(dcopy.ToInt64(v))
+	}
+	if v, ok := from["price"]; ok && v != nil {
+		dest.Price = dcopy.ToFloat64(v)
+	}
+	return nil
+}
+
+func dcopyGenOrderCopyItemFromItem(dest *Item, from *Item) error {
+	dest.Sku = from.Sku
+	dest.Qty = from.Qty
+	dest.Price = from.Price
+	return nil
+}
@@ -0,0 +1,115 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: main.go
+ * @time: 2026/10/19 10:20
+ * @project: deepcopy
+ */
+
+// dcopy-gen 为指定的结构体生成不使用反射的拷贝/转换函数，
+// 生成的函数与 dcopy.StructCopy / dcopy.InstanceToMap / dcopy.InstanceFromMap 使用相同的
+// 标签、命名及类型转换规则，可以把热点类型切换到生成代码上。
+//
+// 用法:
+//
+//	//go:generate dcopy-gen -type=User -from=UserDTO
+//
+// 对于 -type 中的每个类型 T 生成:
+//
+//	func TToMap(from *T) (map[string]interface{}, error)  // 等价于 dcopy.InstanceToMap
+//	func TFromMap(dest *T, from map[string]interface{}) error  // 等价于 dcopy.InstanceFromMap
+//
+// 指定 -from 时，对于一一对应的 T 和 S 额外生成:
+//
+//	func TFromS(dest *T, from *S) error  // 等价于 dcopy.StructCopy
+//
+// 其他包中的结构体、数组以及非基础类型key的map无法静态展开，这些字段仍通过反射转换。
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	typeNames = flag.String("type", "", "逗号分隔的类型名, 必填")
+	fromNames = flag.String("from", "", "逗号分隔的StructCopy来源类型名, 与-type一一对应")
+	output    = flag.String("output", "", "输出文件名, 默认为<type>_dcopy.go")
+	fieldType = flag.String("fieldtype", "idle", "字段名获取方式: idle|origin|json|xorm|gorm|form")
+	timeFmt   = flag.String("timefmt", "2006-01-02 15:04:05", "time.Time类型转换格式")
+	timeType  = flag.String("timetype", "string", "time.Time类型转换成string还是int64")
+	omitempty = flag.Bool("omitempty", false, "是否忽略0字段")
+	nilEmpty  = flag.Bool("nilasempty", false, "nil的map/slice输出为空map/slice")
+	zeroPtr   = flag.Bool("keepzeroptr", false, "omitempty时保留指向0值的指针")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage of dcopy-gen:\n")
+	fmt.Fprintf(os.Stderr, "\tdcopy-gen [flags] -type T [-from S] [directory]\n")
+	fmt.Fprintf(os.Stderr, "Flags:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("dcopy-gen: ")
+	flag.Usage = usage
+	flag.Parse()
+	if len(*typeNames) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if args := flag.Args(); len(args) > 0 {
+		dir = args[0]
+	}
+
+	cfg := config{
+		types:       splitNames(*typeNames),
+		froms:       splitNames(*fromNames),
+		fieldType:   *fieldType,
+		timeFmt:     *timeFmt,
+		timeType:    *timeType,
+		omitempty:   *omitempty,
+		nilAsEmpty:  *nilEmpty,
+		keepZeroPtr: *zeroPtr,
+	}
+	src, err := generate(dir, cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	outName := *output
+	if outName == "" {
+		outName = strings.ToLower(cfg.types[0]) + "_dcopy.go"
+	}
+	if !filepath.IsAbs(outName) {
+		outName = filepath.Join(dir, outName)
+	}
+	if err := ioutil.WriteFile(outName, src, 0644); err != nil {
+		log.Fatalf("writing output: %s", err)
+	}
+}
+
+func splitNames(str string) []string {
+	if str == "" {
+		return nil
+	}
+	out := make([]string, 0, 4)
+	for _, it := range strings.Split(str, ",") {
+		if it = strings.TrimSpace(it); it != "" {
+			out = append(out, it)
+		}
+	}
+	return out
+}
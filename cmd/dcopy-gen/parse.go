@@ -0,0 +1,260 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: parse.go
+ * @time: 2026/10/19 10:31
+ * @project: deepcopy
+ */
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type typeKind int
+
+const (
+	kindOther typeKind = iota
+	kindBasic
+	kindTime
+	kindStruct
+	kindPtr
+	kindSlice
+	kindMap
+	kindInterface
+)
+
+// typeInfo 字段类型的语法描述
+type typeInfo struct {
+	kind  typeKind
+	expr  string    // 类型的源码表示
+	basic string    // kindBasic: 底层基础类型 int/uint8/string...
+	named string    // kindStruct: 当前包中的结构体名
+	key   *typeInfo // kindMap
+	elem  *typeInfo // kindPtr/kindSlice/kindMap
+	pkgs  []string  // 类型表达式中引用的包名
+}
+
+type fieldInfo struct {
+	name      string
+	tag       string
+	anonymous bool
+	exported  bool
+	tp        *typeInfo
+}
+
+type structInfo struct {
+	name   string
+	fields []fieldInfo
+}
+
+// pkgInfo 解析后的包信息
+type pkgInfo struct {
+	name    string
+	fset    *token.FileSet
+	specs   map[string]*ast.TypeSpec
+	imports map[string]string // 包名 -> import path
+	cache   map[string]*structInfo
+}
+
+var basicTypes = map[string]string{
+	"bool": "bool", "string": "string",
+	"int": "int", "int8": "int8", "int16": "int16", "int32": "int32", "int64": "int64",
+	"uint": "uint", "uint8": "uint8", "uint16": "uint16", "uint32": "uint32", "uint64": "uint64",
+	"float32": "float32", "float64": "float64",
+	"byte": "uint8", "rune": "int32", "uintptr": "uintptr",
+}
+
+func loadPackage(dir string) (*pkgInfo, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		name := info.Name()
+		return !strings.HasSuffix(name, "_test.go") && !strings.HasSuffix(name, "_dcopy.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("%d packages found in %s", len(pkgs), dir)
+	}
+	out := &pkgInfo{
+		fset:    fset,
+		specs:   map[string]*ast.TypeSpec{},
+		imports: map[string]string{},
+		cache:   map[string]*structInfo{},
+	}
+	for name, pkg := range pkgs {
+		out.name = name
+		for _, file := range pkg.Files {
+			for _, imp := range file.Imports {
+				path, _ := strconv.Unquote(imp.Path.Value)
+				name := path[strings.LastIndex(path, "/")+1:]
+				if imp.Name != nil {
+					name = imp.Name.Name
+				}
+				out.imports[name] = path
+			}
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					if ts, ok := spec.(*ast.TypeSpec); ok {
+						out.specs[ts.Name.Name] = ts
+					}
+				}
+			}
+		}
+	}
+	return out, nil
+}
+
+// lookupStruct 获取当前包中的结构体定义
+func (p *pkgInfo) lookupStruct(name string) (*structInfo, error) {
+	if it, ok := p.cache[name]; ok {
+		return it, nil
+	}
+	spec, ok := p.specs[name]
+	if !ok {
+		return nil, fmt.Errorf("type %s not found in package %s", name, p.name)
+	}
+	st, ok := spec.Type.(*ast.StructType)
+	if !ok {
+		return nil, fmt.Errorf("type %s is not a struct", name)
+	}
+	out := &structInfo{name: name}
+	p.cache[name] = out
+	for _, field := range st.Fields.List {
+		tag := ""
+		if field.Tag != nil {
+			tag, _ = strconv.Unquote(field.Tag.Value)
+		}
+		tp := p.resolve(field.Type)
+		if len(field.Names) == 0 {
+			// 匿名组合，字段名为类型名
+			name := embeddedName(field.Type)
+			out.fields = append(out.fields, fieldInfo{
+				name:      name,
+				tag:       tag,
+				anonymous: true,
+				exported:  ast.IsExported(name),
+				tp:        tp,
+			})
+			continue
+		}
+		for _, ident := range field.Names {
+			out.fields = append(out.fields, fieldInfo{
+				name:     ident.Name,
+				tag:      tag,
+				exported: ident.IsExported(),
+				tp:       tp,
+			})
+		}
+	}
+	return out, nil
+}
+
+func embeddedName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	}
+	return ""
+}
+
+func (p *pkgInfo) exprString(expr ast.Expr) string {
+	var buf bytes.Buffer
+	_ = printer.Fprint(&buf, p.fset, expr)
+	return buf.String()
+}
+
+// resolve 将字段类型解析成 typeInfo
+func (p *pkgInfo) resolve(expr ast.Expr) *typeInfo {
+	out := &typeInfo{expr: p.exprString(expr)}
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if x, ok := sel.X.(*ast.Ident); ok {
+				out.pkgs = append(out.pkgs, x.Name)
+			}
+			return false
+		}
+		return true
+	})
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if basic, ok := basicTypes[t.Name]; ok {
+			out.kind = kindBasic
+			out.basic = basic
+			return out
+		}
+		spec, ok := p.specs[t.Name]
+		if !ok {
+			return out
+		}
+		switch under := spec.Type.(type) {
+		case *ast.StructType:
+			out.kind = kindStruct
+			out.named = t.Name
+		case *ast.Ident:
+			// type Status int
+			if basic, ok := basicTypes[under.Name]; ok && spec.Assign == 0 {
+				out.kind = kindBasic
+				out.basic = basic
+			}
+		case *ast.ArrayType, *ast.MapType:
+			// type Tags []string, type Labels map[string]string
+			if it := p.resolve(under); it.kind == kindSlice || it.kind == kindMap {
+				out.kind = it.kind
+				out.key = it.key
+				out.elem = it.elem
+			}
+		}
+	case *ast.SelectorExpr:
+		if x, ok := t.X.(*ast.Ident); ok && x.Name == "time" && t.Sel.Name == "Time" {
+			out.kind = kindTime
+		}
+	case *ast.StarExpr:
+		out.kind = kindPtr
+		out.elem = p.resolve(t.X)
+	case *ast.ArrayType:
+		if t.Len == nil {
+			out.kind = kindSlice
+			out.elem = p.resolve(t.Elt)
+		}
+	case *ast.MapType:
+		out.kind = kindMap
+		out.key = p.resolve(t.Key)
+		out.elem = p.resolve(t.Value)
+	case *ast.InterfaceType:
+		out.kind = kindInterface
+	}
+	return out
+}
+
+// structField 转成 reflect.StructField，用于复用 dcopy 的标签解析
+func (f fieldInfo) structField() reflect.StructField {
+	return reflect.StructField{
+		Name:      f.name,
+		Tag:       reflect.StructTag(f.tag),
+		Anonymous: f.anonymous,
+	}
+}
@@ -0,0 +1,44 @@
+package sample
+
+import "time"
+
+type Status int
+
+type Address struct {
+	City string `json:"city"`
+	Zip  string `json:"zip,omitempty"`
+}
+
+type Base struct {
+	ID int64 `json:"id"`
+}
+
+type User struct {
+	Base
+	Name     string            `json:"name"`
+	Age      int               `json:"age,omitempty"`
+	Status   Status            `json:"status"`
+	Score    *float64          `json:"score"`
+	Birthday time.Time         `json:"birthday"`
+	Address  Address           `json:"address"`
+	Home     *Address          `json:"home"`
+	Tags     []string          `json:"tags"`
+	Attrs    map[string]string `json:"attrs"`
+	Extra    interface{}       `json:"extra"`
+	Secret   string            `json:"-"`
+	internal int
+}
+
+type UserDTO struct {
+	Base
+	Name     string
+	Age      int32
+	Status   int
+	Score    float64
+	Birthday time.Time
+	Address  Address
+	Home     *Address
+	Tags     []string
+	Attrs    map[string]string
+	Extra    interface{}
+}
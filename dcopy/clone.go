@@ -0,0 +1,119 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: clone.go
+ * @time: 2026/10/19 15:05
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"reflect"
+	"time"
+)
+
+// cloneState 记录已拷贝过的指针，处理循环引用
+type cloneState struct {
+	visited map[visitKey]reflect.Value
+}
+
+type visitKey struct {
+	ptr uintptr
+	tpe reflect.Type
+}
+
+// cloneValue 同类型深度拷贝，dest必须可设置
+// 指针/map/slice都会重新分配，不与from共享
+func cloneValue(dest, from reflect.Value, state *cloneState, optArgs *args) {
+	if !from.IsValid() {
+		return
+	}
+	switch from.Kind() {
+	case reflect.Ptr:
+		if from.IsNil() {
+			dest.Set(reflect.Zero(dest.Type()))
+			return
+		}
+		key := visitKey{ptr: from.Pointer(), tpe: from.Type()}
+		if it, ok := state.visited[key]; ok {
+			dest.Set(it)
+			return
+		}
+		it := reflect.New(from.Type().Elem())
+		state.visited[key] = it
+		cloneValue(it.Elem(), from.Elem(), state, optArgs)
+		dest.Set(it)
+	case reflect.Interface:
+		if from.IsNil() {
+			dest.Set(reflect.Zero(dest.Type()))
+			return
+		}
+		elem := addressableValue(from.Elem())
+		it := reflect.New(elem.Type()).Elem()
+		cloneValue(it, elem, state, optArgs)
+		dest.Set(it)
+	case reflect.Struct:
+		if isBigType(from.Type()) {
+			bigClone(dest, from)
+			return
+		}
+		// time.Time 只能整体拷贝
+		if from.Type() == reflect.TypeOf(time.Time{}) {
+			dest.Set(from)
+			return
+		}
+		// 逐个字段深度拷贝，UnexportedMode_Skip 时未导出字段为0值，不与from共享
+		dest.Set(reflect.Zero(dest.Type()))
+		from = addressableValue(from)
+		for i := 0; i < from.NumField(); i++ {
+			fieldType := from.Type().Field(i)
+			field, ok := accessibleField(dest.Field(i), fieldType, optArgs)
+			if !ok || !field.CanSet() {
+				continue
+			}
+			fromField, ok := exposeValue(from.Field(i))
+			if !ok {
+				continue
+			}
+			cloneValue(field, fromField, state, optArgs)
+		}
+	case reflect.Map:
+		if from.IsNil() {
+			dest.Set(reflect.Zero(dest.Type()))
+			return
+		}
+		mp := reflect.MakeMapWithSize(from.Type(), from.Len())
+		iter := from.MapRange()
+		for iter.Next() {
+			val := reflect.New(from.Type().Elem()).Elem()
+			cloneValue(val, addressableValue(iter.Value()), state, optArgs)
+			mp.SetMapIndex(iter.Key(), val)
+		}
+		dest.Set(mp)
+	case reflect.Slice:
+		if from.IsNil() {
+			dest.Set(reflect.Zero(dest.Type()))
+			return
+		}
+		sl := reflect.MakeSlice(from.Type(), from.Len(), from.Len())
+		for i := 0; i < from.Len(); i++ {
+			cloneValue(sl.Index(i), from.Index(i), state, optArgs)
+		}
+		dest.Set(sl)
+	case reflect.Array:
+		for i := 0; i < from.Len(); i++ {
+			cloneValue(dest.Index(i), from.Index(i), state, optArgs)
+		}
+	default:
+		dest.Set(from)
+	}
+}
+
+func newCloneState() *cloneState {
+	return &cloneState{visited: map[visitKey]reflect.Value{}}
+}
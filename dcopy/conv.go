@@ -13,9 +13,13 @@
 package dcopy
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
 	"reflect"
 	"strconv"
+	"time"
 )
 
 func interface2String(v interface{}) string {
@@ -25,6 +29,14 @@ func interface2String(v interface{}) string {
 	switch d := v.(type) {
 	case string:
 		return d
+	case json.Number:
+		return d.String()
+	case *big.Int:
+		return d.String()
+	case *big.Float:
+		return d.Text('g', -1)
+	case *big.Rat:
+		return d.RatString()
 	default:
 		return fmt.Sprintf("%v", d)
 	}
@@ -39,6 +51,8 @@ func interface2Int(v interface{}) int {
 	case string:
 		v, _ := strconv.Atoi(d)
 		return v
+	case json.Number, *big.Int, *big.Float, *big.Rat:
+		return int(interface2Int64(d))
 	case float32, float64:
 		return int(reflect.ValueOf(d).Float())
 	case int, int8, int16, int32, int64:
@@ -57,6 +71,20 @@ func interface2Int64(v interface{}) int64 {
 	case string:
 		t, _ := strconv.ParseInt(d, 10, 64)
 		return t
+	case json.Number:
+		if t, err := d.Int64(); err == nil {
+			return t
+		}
+		t, _ := d.Float64()
+		return int64(t)
+	case *big.Int:
+		return d.Int64()
+	case *big.Float:
+		t, _ := d.Int64()
+		return t
+	case *big.Rat:
+		t, _ := new(big.Float).SetRat(d).Int64()
+		return t
 	case float32, float64:
 		return int64(reflect.ValueOf(d).Float())
 	case int, int8, int16, int32, int64:
@@ -75,6 +103,20 @@ func interface2Uint64(v interface{}) uint64 {
 	case string:
 		t, _ := strconv.ParseUint(d, 10, 64)
 		return t
+	case json.Number:
+		if t, err := strconv.ParseUint(d.String(), 10, 64); err == nil {
+			return t
+		}
+		t, _ := d.Float64()
+		return uint64(t)
+	case *big.Int:
+		return d.Uint64()
+	case *big.Float:
+		t, _ := d.Uint64()
+		return t
+	case *big.Rat:
+		t, _ := new(big.Float).SetRat(d).Uint64()
+		return t
 	case float32, float64:
 		return uint64(reflect.ValueOf(d).Float())
 	case int, int8, int16, int32, int64:
@@ -94,6 +136,18 @@ func interface2Float64(v interface{}) float64 {
 	case string:
 		t, _ := strconv.ParseFloat(d, 64)
 		return t
+	case json.Number:
+		t, _ := d.Float64()
+		return t
+	case *big.Int:
+		t, _ := new(big.Float).SetInt(d).Float64()
+		return t
+	case *big.Float:
+		t, _ := d.Float64()
+		return t
+	case *big.Rat:
+		t, _ := d.Float64()
+		return t
 	case float32, float64:
 		return reflect.ValueOf(d).Float()
 	case int, int8, int16, int32, int64:
@@ -114,6 +168,10 @@ func interface2Bool(v interface{}) bool {
 	case string:
 		t, _ := strconv.ParseBool(d)
 		return t
+	case json.Number:
+		return interface2Float64(d) > 0.0
+	case *big.Int, *big.Float, *big.Rat:
+		return interface2Float64(d) > 0.0
 	case float32, float64:
 		return reflect.ValueOf(d).Float() > 0.0
 	case int, int8, int16, int32, int64:
@@ -123,3 +181,295 @@ func interface2Bool(v interface{}) bool {
 	}
 	return false
 }
+
+func interface2BigInt(v interface{}) *big.Int {
+	out := new(big.Int)
+	if v == nil {
+		return out
+	}
+	switch d := v.(type) {
+	case *big.Int:
+		return out.Set(d)
+	case *big.Float:
+		d.Int(out)
+		return out
+	case *big.Rat:
+		return out.Quo(d.Num(), d.Denom())
+	case string, json.Number:
+		str := interface2String(d)
+		if _, ok := out.SetString(str, 10); ok {
+			return out
+		}
+		// 1e3, 1.5 等格式
+		if f, ok := new(big.Float).SetString(str); ok {
+			f.Int(out)
+			return out
+		}
+		return out.SetInt64(0)
+	case float32, float64:
+		big.NewFloat(reflect.ValueOf(d).Float()).Int(out)
+		return out
+	case int, int8, int16, int32, int64:
+		return out.SetInt64(reflect.ValueOf(d).Int())
+	case uint, uint8, uint16, uint32, uint64:
+		return out.SetUint64(reflect.ValueOf(d).Uint())
+	case bool:
+		if d {
+			return out.SetInt64(1)
+		}
+	}
+	return out
+}
+
+func interface2BigFloat(v interface{}) *big.Float {
+	out := new(big.Float)
+	if v == nil {
+		return out
+	}
+	switch d := v.(type) {
+	case *big.Float:
+		return out.Set(d)
+	case *big.Int:
+		return out.SetInt(d)
+	case *big.Rat:
+		return out.SetRat(d)
+	case string, json.Number:
+		if _, ok := out.SetString(interface2String(d)); ok {
+			return out
+		}
+		return new(big.Float)
+	case float32, float64:
+		return out.SetFloat64(reflect.ValueOf(d).Float())
+	case int, int8, int16, int32, int64:
+		return out.SetInt64(reflect.ValueOf(d).Int())
+	case uint, uint8, uint16, uint32, uint64:
+		return out.SetUint64(reflect.ValueOf(d).Uint())
+	case bool:
+		if d {
+			return out.SetInt64(1)
+		}
+	}
+	return out
+}
+
+func interface2BigRat(v interface{}) *big.Rat {
+	out := new(big.Rat)
+	if v == nil {
+		return out
+	}
+	switch d := v.(type) {
+	case *big.Rat:
+		return out.Set(d)
+	case *big.Int:
+		return out.SetInt(d)
+	case *big.Float:
+		if r, _ := d.Rat(out); r != nil {
+			return r
+		}
+		return new(big.Rat)
+	case string, json.Number:
+		if _, ok := out.SetString(interface2String(d)); ok {
+			return out
+		}
+		return new(big.Rat)
+	case float32, float64:
+		if out.SetFloat64(reflect.ValueOf(d).Float()) == nil {
+			return new(big.Rat)
+		}
+		return out
+	case int, int8, int16, int32, int64:
+		return out.SetInt64(reflect.ValueOf(d).Int())
+	case uint, uint8, uint16, uint32, uint64:
+		return out.SetUint64(reflect.ValueOf(d).Uint())
+	case bool:
+		if d {
+			return out.SetInt64(1)
+		}
+	}
+	return out
+}
+
+var (
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bigFloatType = reflect.TypeOf(big.Float{})
+	bigRatType   = reflect.TypeOf(big.Rat{})
+)
+
+func isBigType(tpe reflect.Type) bool {
+	return tpe == bigIntType || tpe == bigFloatType || tpe == bigRatType
+}
+
+// bigDeepCopy 将from转换后写入 big.Int/big.Float/big.Rat 类型的inst
+func bigDeepCopy(inst reflect.Value, from interface{}) {
+	switch inst.Type() {
+	case bigIntType:
+		inst.Addr().Interface().(*big.Int).Set(interface2BigInt(from))
+	case bigFloatType:
+		inst.Addr().Interface().(*big.Float).Set(interface2BigFloat(from))
+	case bigRatType:
+		inst.Addr().Interface().(*big.Rat).Set(interface2BigRat(from))
+	}
+}
+
+// bigClone 同类型big值深度拷贝，不共享底层数据
+func bigClone(inst, from reflect.Value) {
+	if !from.CanAddr() {
+		tmp := reflect.New(from.Type()).Elem()
+		tmp.Set(from)
+		from = tmp
+	}
+	bigDeepCopy(inst, from.Addr().Interface())
+}
+
+// bigToValue big类型转换成map中的值
+// big.Int/big.Float 转成 json.Number 保证序列化后精度不丢失，big.Rat 转成 "a/b" 字符串
+func bigToValue(field reflect.Value) (interface{}, bool) {
+	if !isBigType(field.Type()) {
+		return nil, false
+	}
+	if !field.CanAddr() {
+		tmp := reflect.New(field.Type()).Elem()
+		tmp.Set(field)
+		field = tmp
+	}
+	switch d := field.Addr().Interface().(type) {
+	case *big.Int:
+		return json.Number(d.String()), true
+	case *big.Float:
+		return json.Number(d.Text('g', -1)), true
+	case *big.Rat:
+		return d.RatString(), true
+	}
+	return nil, false
+}
+
+// toGenericValue 将任意值转换成 InstanceToMap 输出的通用数据
+// struct -> map[string]interface{}, slice -> []interface{}, time.Time -> 按 timeValType 转换
+func toGenericValue(from reflect.Value, optArgs *args) (interface{}, error) {
+	if !from.IsValid() {
+		return nil, nil
+	}
+	switch from.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if from.IsNil() {
+			return nil, nil
+		}
+		return toGenericValue(from.Elem(), optArgs)
+	case reflect.Struct:
+		if t, ok := from.Interface().(time.Time); ok {
+			if optArgs.timeValType == TimeValType_Int64 {
+				return t.Unix(), nil
+			}
+			return t.Format(optArgs.timeFmtStr), nil
+		}
+		if val, ok := bigToValue(from); ok {
+			return val, nil
+		}
+		subMap := make(map[string]interface{}, from.NumField())
+		err := instanceToMap(subMap, from, 0, optArgs)
+		return subMap, err
+	case reflect.Map:
+		subMap := make(map[string]interface{}, from.Len())
+		err := instanceMapToMap(subMap, from, 0, optArgs)
+		return subMap, err
+	case reflect.Slice:
+		subSlice := make([]interface{}, from.Len())
+		err := instanceSliceToArr(subSlice, from, 0, optArgs)
+		return subSlice, err
+	default:
+		return getBasicValue(from.Interface()), nil
+	}
+}
+
+func isNumberValue(v interface{}) bool {
+	switch v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64,
+		json.Number, *big.Int, *big.Float, *big.Rat:
+		return true
+	}
+	return false
+}
+
+// checkConvertible 检查通用数据v能否无损失地按 valueDeepCopy 的规则写入tpe类型
+// valueDeepCopy 遇到无法转换的数据会静默写入0值，StructCopy 的严格模式依赖此检查
+func checkConvertible(tpe reflect.Type, v interface{}, optArgs *args) error {
+	if v == nil {
+		return nil
+	}
+	for tpe.Kind() == reflect.Ptr {
+		tpe = tpe.Elem()
+	}
+	str, isStr := v.(string)
+	var err error
+	switch tpe.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if isStr {
+			_, err = strconv.ParseInt(str, 10, 64)
+		} else if !isNumberValue(v) {
+			err = errors.New("not number")
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if isStr {
+			_, err = strconv.ParseUint(str, 10, 64)
+		} else if !isNumberValue(v) {
+			err = errors.New("not number")
+		}
+	case reflect.Float32, reflect.Float64:
+		if isStr {
+			_, err = strconv.ParseFloat(str, 64)
+		} else if !isNumberValue(v) {
+			err = errors.New("not number")
+		}
+	case reflect.Bool:
+		if isStr {
+			_, err = strconv.ParseBool(str)
+		} else if _, ok := v.(bool); !ok && !isNumberValue(v) {
+			err = errors.New("not bool")
+		}
+	case reflect.String:
+		if _, ok := v.(bool); !ok && !isStr && !isNumberValue(v) {
+			err = errors.New("not basic type")
+		}
+	case reflect.Struct:
+		switch {
+		case tpe == reflect.TypeOf(time.Time{}):
+			if optArgs.timeValType == TimeValType_String {
+				_, err = time.ParseInLocation(optArgs.timeFmtStr, interface2String(v), time.Local)
+			} else if isStr {
+				_, err = strconv.ParseInt(str, 10, 64)
+			} else if !isNumberValue(v) {
+				err = errors.New("not timestamp")
+			}
+		case isBigType(tpe):
+			if isStr {
+				if _, ok := new(big.Rat).SetString(str); !ok {
+					err = errors.New("not number")
+				}
+			} else if !isNumberValue(v) {
+				err = errors.New("not number")
+			}
+		default:
+			if _, ok := v.(map[string]interface{}); !ok {
+				err = errors.New("not map")
+			}
+		}
+	case reflect.Map:
+		if _, ok := v.(map[string]interface{}); !ok {
+			err = errors.New("not map")
+		}
+	case reflect.Slice:
+		if _, ok := v.([]interface{}); !ok {
+			err = errors.New("not slice")
+		}
+	case reflect.Interface:
+		if !reflect.TypeOf(v).AssignableTo(tpe) {
+			err = errors.New("not assignable")
+		}
+	default:
+		err = errors.New("unsupported type")
+	}
+	if err != nil {
+		return fmt.Errorf("cannot convert %T to %s: %v", v, tpe, err)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: conv_test.go
+ * @time: 2026/10/19 18:02
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestInterface2Number(t *testing.T) {
+	bigInt, _ := new(big.Int).SetString("9007199254740993", 10)
+	tests := []struct {
+		name    string
+		v       interface{}
+		wantI64 int64
+		wantU64 uint64
+		wantF64 float64
+		wantStr string
+	}{
+		{
+			name:    "json.Number_int",
+			v:       json.Number("9007199254740993"),
+			wantI64: 9007199254740993,
+			wantU64: 9007199254740993,
+			wantF64: 9007199254740992,
+			wantStr: "9007199254740993",
+		},
+		{
+			name:    "json.Number_float",
+			v:       json.Number("1.5"),
+			wantI64: 1,
+			wantU64: 1,
+			wantF64: 1.5,
+			wantStr: "1.5",
+		},
+		{
+			name:    "big.Int",
+			v:       bigInt,
+			wantI64: 9007199254740993,
+			wantU64: 9007199254740993,
+			wantF64: 9007199254740992,
+			wantStr: "9007199254740993",
+		},
+		{
+			name:    "big.Float",
+			v:       big.NewFloat(2.5),
+			wantI64: 2,
+			wantU64: 2,
+			wantF64: 2.5,
+			wantStr: "2.5",
+		},
+		{
+			name:    "big.Rat",
+			v:       big.NewRat(7, 2),
+			wantI64: 3,
+			wantU64: 3,
+			wantF64: 3.5,
+			wantStr: "7/2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := interface2Int64(tt.v); got != tt.wantI64 {
+				t.Errorf("interface2Int64() = %v, want %v", got, tt.wantI64)
+			}
+			if got := interface2Uint64(tt.v); got != tt.wantU64 {
+				t.Errorf("interface2Uint64() = %v, want %v", got, tt.wantU64)
+			}
+			if got := interface2Float64(tt.v); got != tt.wantF64 {
+				t.Errorf("interface2Float64() = %v, want %v", got, tt.wantF64)
+			}
+			if got := interface2String(tt.v); got != tt.wantStr {
+				t.Errorf("interface2String() = %v, want %v", got, tt.wantStr)
+			}
+			if got := interface2Bool(tt.v); !got {
+				t.Errorf("interface2Bool() = %v, want true", got)
+			}
+		})
+	}
+}
+
+func TestInterface2Big(t *testing.T) {
+	if got := interface2BigInt(json.Number("123456789012345678901234567890")).String(); got != "123456789012345678901234567890" {
+		t.Errorf("interface2BigInt() = %v", got)
+	}
+	if got := interface2BigInt("1e3").String(); got != "1000" {
+		t.Errorf("interface2BigInt() = %v", got)
+	}
+	if got := interface2BigFloat("0.1").Text('g', -1); got != "0.1" {
+		t.Errorf("interface2BigFloat() = %v", got)
+	}
+	if got := interface2BigRat(json.Number("0.25")).RatString(); got != "1/4" {
+		t.Errorf("interface2BigRat() = %v", got)
+	}
+	if got := interface2BigRat(int64(3)).RatString(); got != "3" {
+		t.Errorf("interface2BigRat() = %v", got)
+	}
+}
+
+type bigFoo struct {
+	ID     int64      `json:"id"`
+	Amount *big.Int   `json:"amount"`
+	Price  big.Float  `json:"price"`
+	Ratio  *big.Rat   `json:"ratio"`
+	List   []*big.Int `json:"list"`
+}
+
+func TestBigRoundTrip(t *testing.T) {
+	data := []byte(`{"id":9007199254740993,"amount":123456789012345678901234567890,"price":"0.1","ratio":"1/3","list":[1,"2"]}`)
+	got := &bigFoo{}
+	if err := InstanceFromBytes(got, data); err != nil {
+		t.Fatalf("InstanceFromBytes() error = %v", err)
+	}
+	if got.ID != 9007199254740993 {
+		t.Errorf("InstanceFromBytes() id = %v", got.ID)
+	}
+	if got.Amount == nil || got.Amount.String() != "123456789012345678901234567890" {
+		t.Errorf("InstanceFromBytes() amount = %v", got.Amount)
+	}
+	if got.Price.Text('g', -1) != "0.1" || got.Ratio.RatString() != "1/3" || len(got.List) != 2 || got.List[1].Int64() != 2 {
+		t.Errorf("InstanceFromBytes() = %+v", got)
+	}
+
+	out, err := InstanceToMap(got)
+	if err != nil {
+		t.Fatalf("InstanceToMap() error = %v", err)
+	}
+	if out["amount"] != json.Number("123456789012345678901234567890") || out["ratio"] != "1/3" || out["price"] != json.Number("0.1") {
+		t.Errorf("InstanceToMap() = %v", out)
+	}
+
+	back := &bigFoo{}
+	if err := InstanceFromMap(back, out); err != nil {
+		t.Fatalf("InstanceFromMap() error = %v", err)
+	}
+	if back.Amount.Cmp(got.Amount) != 0 || back.Ratio.Cmp(got.Ratio) != 0 {
+		t.Errorf("InstanceFromMap() = %+v", back)
+	}
+}
@@ -0,0 +1,80 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: copy.go
+ * @time: 2026/10/19 19:40
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// InstanceCopy 任意类型之间的拷贝，dest必须为指针
+//   - struct -> struct: 同 StructCopy
+//   - 类型兼容的slice/map，如 []SrcItem -> []DstItem, map[K]SrcItem -> map[K]DstItem: 按元素深度拷贝
+//   - 其他组合，如 struct -> map[string]T, map[string]string -> struct: 先转换成通用数据，再按 InstanceFromMap 的规则写入
+//
+// 命名沿用 InstanceFromMap/InstanceToMap 的 Instance 前缀，Copy 已是返回新对象的泛型版 Copy[D, S]
+func InstanceCopy(dest interface{}, from interface{}, opts ...CopyOption) (err error) {
+	optArgs := newOpts(opts...)
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New(interface2String(r))
+			printLog(&optArgs, 0, r)
+		}
+	}()
+
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() != reflect.Ptr || destValue.IsNil() {
+		return errors.New("dest not ptr type")
+	}
+	destValue = destValue.Elem()
+	fromValue := reflect.ValueOf(from)
+	for fromValue.Kind() == reflect.Ptr || fromValue.Kind() == reflect.Interface {
+		if fromValue.IsNil() {
+			return nil
+		}
+		fromValue = fromValue.Elem()
+	}
+	if !fromValue.IsValid() {
+		return nil
+	}
+
+	destElem := destValue
+	for destElem.Kind() == reflect.Ptr {
+		if destElem.IsNil() {
+			destElem.Set(reflect.New(destElem.Type().Elem()))
+		}
+		destElem = destElem.Elem()
+	}
+	if destElem.Kind() == reflect.Struct && isNestedStruct(destElem.Type()) &&
+		fromValue.Kind() == reflect.Struct && isNestedStruct(fromValue.Type()) {
+		_, err = StructCopyWithReport(destElem.Addr().Interface(), fromValue.Interface(), opts...)
+		return err
+	}
+
+	if isFieldTypeMatch(destValue.Type(), fromValue.Type()) {
+		state := newStructCopyState(&optArgs)
+		copyValue(destValue, fromValue, "", state)
+		if optArgs.strict && len(state.unconvertible) > 0 {
+			return fmt.Errorf("fields cannot be converted: %s", strings.Join(state.unconvertible, ", "))
+		}
+		return nil
+	}
+
+	v, err := toGenericValue(fromValue, &optArgs)
+	if err != nil {
+		return err
+	}
+	return valueDeepCopy(destValue, v, 0, "", &optArgs)
+}
@@ -0,0 +1,28 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: copy_report.go
+ * @time: 2026/10/19 19:05
+ * @project: deepcopy
+ */
+
+package dcopy
+
+// CopyReport StructCopyWithReport 返回的字段拷贝明细
+// 目标字段使用go字段名路径，如 "Address.City"；SourceUnused 使用来源结构体的字段路径
+type CopyReport struct {
+	Copied            []string // 已拷贝的目标字段
+	MissingInSource   []string // 来源中找不到(或途经nil的匿名组合指针)的目标字段
+	TypeMismatch      []string // 类型不匹配且无法转换的目标字段
+	UnexportedSkipped []string // 跳过的未导出目标字段
+	SourceUnused      []string // 没有拷贝到目标的来源字段
+}
+
+// FullCoverage 两侧字段是否全部一一对应
+func (r *CopyReport) FullCoverage() bool {
+	return len(r.MissingInSource) == 0 && len(r.TypeMismatch) == 0 && len(r.SourceUnused) == 0
+}
@@ -0,0 +1,93 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: copy_test.go
+ * @time: 2026/10/19 19:55
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInstanceCopy(t *testing.T) {
+	type SrcItem struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+	type DstItem struct {
+		Name string `json:"name"`
+		Age  int64  `json:"age"`
+	}
+
+	tests := []struct {
+		name string
+		dest interface{}
+		from interface{}
+		want interface{}
+	}{
+		{
+			name: "struct_to_struct",
+			dest: &DstItem{},
+			from: SrcItem{Name: "a", Age: 1},
+			want: &DstItem{Name: "a", Age: 1},
+		},
+		{
+			name: "struct_to_typed_map",
+			dest: &map[string]string{},
+			from: &SrcItem{Name: "a", Age: 1},
+			want: &map[string]string{"name": "a", "age": "1"},
+		},
+		{
+			name: "typed_map_to_struct",
+			dest: &DstItem{},
+			from: map[string]int{"age": 18},
+			want: &DstItem{Age: 18},
+		},
+		{
+			name: "string_map_to_struct",
+			dest: &DstItem{},
+			from: map[string]string{"name": "b", "age": "20"},
+			want: &DstItem{Name: "b", Age: 20},
+		},
+		{
+			name: "slice_of_struct",
+			dest: &[]DstItem{},
+			from: []SrcItem{{Name: "a", Age: 1}, {Name: "b", Age: 2}},
+			want: &[]DstItem{{Name: "a", Age: 1}, {Name: "b", Age: 2}},
+		},
+		{
+			name: "map_of_struct",
+			dest: &map[int]*DstItem{},
+			from: map[int]SrcItem{1: {Name: "a", Age: 1}},
+			want: &map[int]*DstItem{1: {Name: "a", Age: 1}},
+		},
+		{
+			name: "ptr_to_ptr",
+			dest: new(*DstItem),
+			from: &SrcItem{Name: "c"},
+			want: func() **DstItem { it := &DstItem{Name: "c"}; return &it }(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := InstanceCopy(tt.dest, tt.from); err != nil {
+				t.Fatalf("InstanceCopy() error = %v", err)
+			}
+			if !reflect.DeepEqual(tt.dest, tt.want) {
+				t.Errorf("InstanceCopy() = %+v, want %+v", reflect.ValueOf(tt.dest).Elem(), reflect.ValueOf(tt.want).Elem())
+			}
+		})
+	}
+
+	if err := InstanceCopy(DstItem{}, SrcItem{}); err == nil {
+		t.Errorf("InstanceCopy() to non-pointer should fail")
+	}
+}
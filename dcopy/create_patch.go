@@ -0,0 +1,164 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: create_patch.go
+ * @time: 2026/10/20 10:30
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// CreateJSONPatch 生成把oldVal变成newVal的 JSON Patch(RFC 6902)
+// 两者先按 InstanceToMap 的规则转换(字段名、WithOmitempty、时间格式等选项一致)，再逐层比较
+// 对象按key生成 add/remove/replace，数组按下标比较，多出的元素 add，缺少的元素从末尾 remove
+// 生成的patch可以用相同选项的 ApplyJSONPatch 应用到oldVal的副本上
+func CreateJSONPatch(oldVal, newVal interface{}, opts ...CopyOption) ([]byte, error) {
+	from, to, err := patchGenericValues(oldVal, newVal, opts...)
+	if err != nil {
+		return nil, err
+	}
+	ops := make([]patchOperation, 0, 8)
+	if err := createJSONPatch(&ops, from, to, ""); err != nil {
+		return nil, err
+	}
+	return json.Marshal(ops)
+}
+
+// CreateMergePatch 生成把oldVal变成newVal的 JSON Merge Patch(RFC 7386)
+// 两者先按 InstanceToMap 的规则转换，删除的key为null，对象递归比较，数组整体替换
+// 生成的patch可以用相同选项的 ApplyMergePatch 应用到oldVal的副本上
+func CreateMergePatch(oldVal, newVal interface{}, opts ...CopyOption) ([]byte, error) {
+	from, to, err := patchGenericValues(oldVal, newVal, opts...)
+	if err != nil {
+		return nil, err
+	}
+	fromMap, ok1 := from.(map[string]interface{})
+	toMap, ok2 := to.(map[string]interface{})
+	if !ok1 || !ok2 {
+		// 不是对象时整体替换
+		return json.Marshal(to)
+	}
+	return json.Marshal(createMergePatch(fromMap, toMap))
+}
+
+// patchGenericValues 转换成 InstanceToMap 输出的通用数据
+func patchGenericValues(oldVal, newVal interface{}, opts ...CopyOption) (from, to interface{}, err error) {
+	optArgs := newOpts(opts...)
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("create patch err=[%v]", r)
+			printLog(&optArgs, 0, r)
+		}
+	}()
+	if from, err = toGenericValue(addressableValue(reflect.ValueOf(oldVal)), &optArgs); err != nil {
+		return
+	}
+	to, err = toGenericValue(addressableValue(reflect.ValueOf(newVal)), &optArgs)
+	return
+}
+
+func createJSONPatch(ops *[]patchOperation, from, to interface{}, pointer string) error {
+	fromMap, ok1 := from.(map[string]interface{})
+	toMap, ok2 := to.(map[string]interface{})
+	if ok1 && ok2 {
+		for _, key := range sortedKeys(fromMap) {
+			if _, ok := toMap[key]; !ok {
+				*ops = append(*ops, removeOperation(pointer+"/"+escapePointer(key)))
+			}
+		}
+		for _, key := range sortedKeys(toMap) {
+			child := pointer + "/" + escapePointer(key)
+			old, ok := fromMap[key]
+			if !ok {
+				if err := appendPatchOperation(ops, "add", child, toMap[key]); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := createJSONPatch(ops, old, toMap[key], child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	fromArr, ok1 := from.([]interface{})
+	toArr, ok2 := to.([]interface{})
+	if ok1 && ok2 {
+		for i := 0; i < len(fromArr) && i < len(toArr); i++ {
+			if err := createJSONPatch(ops, fromArr[i], toArr[i], fmt.Sprintf("%s/%d", pointer, i)); err != nil {
+				return err
+			}
+		}
+		for i := len(fromArr); i < len(toArr); i++ {
+			if err := appendPatchOperation(ops, "add", fmt.Sprintf("%s/%d", pointer, i), toArr[i]); err != nil {
+				return err
+			}
+		}
+		// 从末尾删除，前面的下标不变
+		for i := len(fromArr) - 1; i >= len(toArr); i-- {
+			*ops = append(*ops, removeOperation(fmt.Sprintf("%s/%d", pointer, i)))
+		}
+		return nil
+	}
+	if jsonEqual(from, to) {
+		return nil
+	}
+	return appendPatchOperation(ops, "replace", pointer, to)
+}
+
+func appendPatchOperation(ops *[]patchOperation, op, pointer string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	*ops = append(*ops, patchOperation{Op: op, Path: &pointer, Value: data})
+	return nil
+}
+
+func removeOperation(pointer string) patchOperation {
+	return patchOperation{Op: "remove", Path: &pointer}
+}
+
+func createMergePatch(from, to map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	for key := range from {
+		if _, ok := to[key]; !ok {
+			out[key] = nil
+		}
+	}
+	for key, val := range to {
+		old, ok := from[key]
+		if !ok {
+			out[key] = val
+			continue
+		}
+		oldMap, ok1 := old.(map[string]interface{})
+		newMap, ok2 := val.(map[string]interface{})
+		if ok1 && ok2 {
+			if sub := createMergePatch(oldMap, newMap); len(sub) > 0 {
+				out[key] = sub
+			}
+			continue
+		}
+		if !jsonEqual(old, val) {
+			out[key] = val
+		}
+	}
+	return out
+}
+
+// escapePointer JSON Pointer 转义，~ 转成 ~0，/ 转成 ~1
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
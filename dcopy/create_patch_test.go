@@ -0,0 +1,113 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: create_patch_test.go
+ * @time: 2026/10/20 10:30
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"testing"
+	"time"
+)
+
+func createPatchCases() []struct {
+	name   string
+	modify func(o *diffOrder)
+} {
+	return []struct {
+		name   string
+		modify func(o *diffOrder)
+	}{
+		{name: "field", modify: func(o *diffOrder) { o.Name = "o2" }},
+		{name: "sliceGrow", modify: func(o *diffOrder) { o.Lines = append(o.Lines, diffLine{ID: 3, Sku: "c"}) }},
+		{name: "sliceShrink", modify: func(o *diffOrder) { o.Tags = o.Tags[:1]; o.Lines[0].Qty = 9 }},
+		{name: "sliceClear", modify: func(o *diffOrder) { o.Tags = nil }},
+		{name: "mapKeys", modify: func(o *diffOrder) { o.Attrs = map[string]string{"k": "v2", "a/b~": "x"} }},
+		{name: "ptr", modify: func(o *diffOrder) { o.Owner = &diffLine{ID: 9, Sku: "z"} }},
+		{name: "interface", modify: func(o *diffOrder) { o.Extra = map[string]interface{}{"a": []interface{}{1, "b"}} }},
+		{name: "time", modify: func(o *diffOrder) { o.Created = o.Created.Add(time.Hour) }},
+		{name: "same", modify: func(o *diffOrder) {}},
+	}
+}
+
+func newCreatePatchOrder() diffOrder {
+	return diffOrder{
+		Name:    "o1",
+		Lines:   []diffLine{{ID: 1, Sku: "a", Qty: 1}, {ID: 2, Sku: "b", Qty: 2}},
+		Tags:    []string{"x", "y"},
+		Attrs:   map[string]string{"k": "v"},
+		Owner:   &diffLine{ID: 1},
+		Extra:   "e",
+		Created: time.Date(2026, 10, 19, 8, 0, 0, 0, time.Local),
+	}
+}
+
+func TestCreateJSONPatch(t *testing.T) {
+	a := newCreatePatchOrder()
+	b := newCreatePatchOrder()
+	b.Name = "o2"
+	b.Tags = append(b.Tags, "z")
+	b.Attrs = map[string]string{"a/b": "1"}
+	got, err := CreateJSONPatch(a, &b)
+	want := `[{"op":"remove","path":"/attrs/k"},{"op":"add","path":"/attrs/a~1b","value":"1"},{"op":"replace","path":"/name","value":"o2"},{"op":"add","path":"/tags/2","value":"z"}]`
+	if err != nil || string(got) != want {
+		t.Errorf("CreateJSONPatch() = %s, %v, want %s", got, err, want)
+	}
+
+	for _, tt := range createPatchCases() {
+		t.Run("TestCreateJSONPatch_"+tt.name, func(t *testing.T) {
+			a, b := newCreatePatchOrder(), newCreatePatchOrder()
+			tt.modify(&b)
+			patch, err := CreateJSONPatch(a, b)
+			if err != nil {
+				t.Fatalf("CreateJSONPatch() error = %v", err)
+			}
+			if err := ApplyJSONPatch(&a, patch); err != nil {
+				t.Fatalf("ApplyJSONPatch(%s) error = %v", patch, err)
+			}
+			if changes := Diff(a, b); len(changes) > 0 {
+				t.Errorf("round trip %s: diff = %+v", patch, changes)
+			}
+		})
+	}
+}
+
+func TestCreateMergePatch(t *testing.T) {
+	a := newCreatePatchOrder()
+	b := newCreatePatchOrder()
+	b.Name = "o2"
+	b.Owner.Sku = "s"
+	b.Attrs = nil
+	got, err := CreateMergePatch(a, b, WithOmitempty(true))
+	want := `{"attrs":null,"name":"o2","owner":{"sku":"s"}}`
+	if err != nil || string(got) != want {
+		t.Errorf("CreateMergePatch() = %s, %v, want %s", got, err, want)
+	}
+	if got, err := CreateMergePatch(1, "x"); err != nil || string(got) != `"x"` {
+		t.Errorf("CreateMergePatch() = %s, %v", got, err)
+	}
+
+	for _, tt := range createPatchCases() {
+		t.Run("TestCreateMergePatch_"+tt.name, func(t *testing.T) {
+			a, b := newCreatePatchOrder(), newCreatePatchOrder()
+			tt.modify(&b)
+			patch, err := CreateMergePatch(a, b)
+			if err != nil {
+				t.Fatalf("CreateMergePatch() error = %v", err)
+			}
+			if _, err := ApplyMergePatch(&a, patch); err != nil {
+				t.Fatalf("ApplyMergePatch(%s) error = %v", patch, err)
+			}
+			if changes := Diff(a, b); len(changes) > 0 {
+				t.Errorf("round trip %s: diff = %+v", patch, changes)
+			}
+		})
+	}
+}
@@ -12,7 +12,7 @@
 package dcopy
 
 import (
-	"encoding/json"
+	"bytes"
 	"errors"
 	"fmt"
 	"reflect"
@@ -31,6 +31,7 @@ const (
 	FieldType_Json
 	FieldType_Xorm
 	FieldType_Gorm
+	FieldType_Form // form表单，form tag未指定时按json tag
 )
 
 const (
@@ -38,12 +39,35 @@ const (
 	TimeValType_String                 // 格式化时间字符串
 )
 
+type MergeStrategy int8
+
+const (
+	MergeStrategy_Replace      MergeStrategy = 0 + iota // map/slice/指针整体替换
+	MergeStrategy_Merge                                 // 合并到已有的map，复用已有指针指向的对象，slice整体替换
+	MergeStrategy_AppendSlices                          // 同Merge，slice追加到已有数据之后
+)
+
 type args struct {
 	curGetFieldType FieldType           // 字段名获取方式
 	omitempty       bool                // 是否忽略0字段
+	nilAsEmpty      bool                // InstanceToMap时nil的map/slice输出为空map/slice；Equal时nil与空的map/slice视为相同
+	keepZeroPtr     bool                // InstanceToMap时指向0值的指针不受omitempty影响
 	timeFmtStr      string              // time.Time类型转换格式
 	timeValType     int8                // time.Time类型转换成timestamp还是字符串
 	ignoreFieldMap  map[string]struct{} // 需要忽略的字段
+	onlyFieldMap    map[string]struct{} // StructCopy时只拷贝的字段
+	unexportedMode  UnexportedMode      // 未导出字段的处理方式
+	mergeStrategy   MergeStrategy       // 目标已有数据时的合并方式
+	convertTypes    bool                // StructCopy时是否转换不匹配的字段类型
+	strict          bool                // StructCopy时存在无法转换的字段是否返回错误
+	srcTag          string              // StructCopy时来源结构体字段名使用的tag
+	destTag         string              // StructCopy时目标结构体字段名使用的tag
+	caseInsensitive bool                // StructCopy时字段名匹配忽略大小写
+	fieldMapping    map[string]string   // StructCopy时 目标字段路径 -> 来源字段路径
+	timeEqual       bool                // Equal时time.Time只比较时间点，不要求时区一致
+	numericEqual    bool                // Equal时不同类型的数字按数值比较
+	resolver        ConflictResolver    // Merge3时解决冲突的方式
+	envSep          string              // InstanceFromEnv 时slice的分隔符，默认为 ,
 	log             logrus.StdLogger    // 打印日志
 }
 
@@ -71,6 +95,22 @@ func WithOmitempty(omitempty bool) CopyOption {
 	}
 }
 
+// WithNilAsEmpty InstanceToMap时nil的map/slice输出为空的map/slice，默认输出nil
+// Equal时nil与空的map/slice视为相同
+func WithNilAsEmpty(nilAsEmpty bool) CopyOption {
+	return func(a *args) {
+		a.nilAsEmpty = nilAsEmpty
+	}
+}
+
+// WithKeepZeroPtr InstanceToMap时非nil但指向0值的指针(如 *int 指向 0)，omitempty时是否保留
+// 默认同普通字段一样忽略；保留时只有nil指针会被忽略，同 encoding/json
+func WithKeepZeroPtr(keep bool) CopyOption {
+	return func(a *args) {
+		a.keepZeroPtr = keep
+	}
+}
+
 func WithTimeFormatStr(format string) CopyOption {
 	return func(a *args) {
 		a.timeFmtStr = format
@@ -94,6 +134,14 @@ func WithIgnoreFields(fieldNames ...string) CopyOption {
 	}
 }
 
+// WithMergeStrategy 目标对象已有数据时的合并方式，默认整体替换
+// 用于默认值 -> 配置文件 -> 环境变量 逐层覆盖的场景
+func WithMergeStrategy(strategy MergeStrategy) CopyOption {
+	return func(a *args) {
+		a.mergeStrategy = strategy
+	}
+}
+
 // WitLog 打印日志
 func WitLog() CopyOption {
 	return func(a *args) {
@@ -166,7 +214,8 @@ func parseTagName(field reflect.StructField, tag string) (name string, omitempty
 	if handle, ok := parseHandle[tag]; ok {
 		return handle(name)
 	}
-	return
+	// 其他tag按json格式解析, 如 form:"name,omitempty"
+	return parseJsonTag(name)
 }
 
 // 获取字段名优先级, json tag -> gorm tag -> xorm tag -> FileName, 如果没有则使用字段名的小驼峰格式
@@ -209,6 +258,18 @@ func getFieldTag(fieldType reflect.StructField, optArgs *args) (fieldName string
 		}
 		fieldName = littleCamelCase(fieldType.Name)
 		return
+	case FieldType_Form:
+		for _, tag := range []string{"form", "json"} {
+			fieldName, omitempty, ignore = parseTagName(fieldType, tag)
+			if len(fieldName) > 0 || ignore {
+				if ignore {
+					fieldName = littleCamelCase(fieldType.Name)
+				}
+				return
+			}
+		}
+		fieldName = littleCamelCase(fieldType.Name)
+		return
 	default:
 		fieldName, omitempty, ignore = parseTagName(fieldType, "json")
 		if len(fieldName) > 0 || ignore {
@@ -272,13 +333,10 @@ func newOpts(opts ...CopyOption) args {
 	return opt
 }
 
+// InstanceFromBytes 解析json数据到dest
+// 数字按 UseNumber 处理，超过2^53的int64不会经过float64丢失精度
 func InstanceFromBytes(dest interface{}, from []byte, opts ...CopyOption) (err error) {
-	// optArgs := newOpts(opts...)
-	tmp := map[string]interface{}{}
-	if err := json.Unmarshal(from, &tmp); err != nil {
-		return err
-	}
-	return InstanceFromMap(dest, tmp, opts...)
+	return InstanceFromReader(dest, bytes.NewReader(from), opts...)
 }
 
 func InstanceFromMap(dest interface{}, from interface{}, opts ...CopyOption) (err error) {
@@ -343,6 +401,10 @@ func valueDeepCopy(inst reflect.Value, from interface{}, deep int, fieldName str
 		val := reflect.ValueOf(from)
 		inst.Set(val)
 	case reflect.Ptr:
+		if optArgs.mergeStrategy != MergeStrategy_Replace && !inst.IsNil() {
+			// 复用已有的对象
+			return valueDeepCopy(inst.Elem(), from, deep+1, fieldName, optArgs)
+		}
 		it := reflect.New(inst.Type().Elem())
 		printLog(optArgs, deep, "Ptr>>:", it.String())
 
@@ -365,30 +427,29 @@ func valueDeepCopy(inst reflect.Value, from interface{}, deep int, fieldName str
 			}
 			return nil
 		}
+		if isBigType(inst.Type()) {
+			bigDeepCopy(inst, from)
+			return nil
+		}
 		if mp, ok := from.(map[string]interface{}); ok {
 			printLog(optArgs, deep, "Struct>>:", inst.String())
 
-			tpe := inst.Type()
-			for i := 0; i < tpe.NumField(); i += 1 {
-				fieldType := inst.Type().Field(i)
-				field := inst.Field(i)
-
-				fieldName, _, ignore := getFieldTag(fieldType, optArgs)
-				if ignore {
+			for _, sf := range structFields(inst.Type(), optArgs) {
+				fieldValue, ok := mp[sf.name]
+				if sf.inlineMap {
+					fieldValue, ok = mp, true
+				}
+				if !ok || fieldValue == nil {
 					continue
 				}
-
-				if fieldType.Anonymous {
-					valueDeepCopy(field, mp, deep+1, fieldName, optArgs)
-				} else {
-					fieldValue, ok := mp[fieldName]
-					if !ok || fieldValue == nil {
-						continue
-					}
-					err = valueDeepCopy(field, fieldValue, deep+1, fieldName, optArgs)
-					if err != nil {
-						return
-					}
+				// 匿名组合的nil指针只在有数据时分配
+				field, ok := exposeValue(streamFieldByIndex(inst, sf.index))
+				if !ok || !field.IsValid() {
+					continue
+				}
+				err = valueDeepCopy(field, fieldValue, deep+1, sf.name, optArgs)
+				if err != nil {
+					return
 				}
 				// printlog(getDeepIndident(deep+1),"field name:", fieldName, "value:", field.Interface(), "kind:",field.Kind())
 			}
@@ -397,6 +458,9 @@ func valueDeepCopy(inst reflect.Value, from interface{}, deep int, fieldName str
 	case reflect.Map:
 		if vv, ok := from.(map[string]interface{}); ok {
 			mp := reflect.MakeMap(inst.Type())
+			if optArgs.mergeStrategy != MergeStrategy_Replace && !inst.IsNil() {
+				mp = inst
+			}
 			printLog(optArgs, deep, "Map>>:", mp.String())
 
 			err = mapValueDeepCopy(mp, vv, deep+1, fieldName, optArgs)
@@ -407,6 +471,19 @@ func valueDeepCopy(inst reflect.Value, from interface{}, deep int, fieldName str
 		}
 	case reflect.Slice:
 		if vv, ok := from.([]interface{}); ok {
+			if optArgs.mergeStrategy == MergeStrategy_AppendSlices && inst.Len() > 0 {
+				n := inst.Len()
+				sl := reflect.MakeSlice(inst.Type(), n+len(vv), n+len(vv))
+				reflect.Copy(sl, inst)
+				printLog(optArgs, deep, "Slice>>:", sl.String())
+
+				err = sliceValueDeepCopy(sl.Slice(n, n+len(vv)), vv, deep+1, fieldName, optArgs)
+				if err != nil {
+					return
+				}
+				inst.Set(sl)
+				break
+			}
 			sl := reflect.MakeSlice(inst.Type(), len(vv), cap(vv))
 			printLog(optArgs, deep, "Slice>>:", sl.String())
 
@@ -446,6 +523,9 @@ func mapValueDeepCopy(inst reflect.Value, data map[string]interface{}, deep int,
 			val = reflect.ValueOf(v)
 		case reflect.Struct: // map[string]TestStruct
 			val = reflect.New(inst.Type().Elem()).Elem()
+			if existing := mergeMapValue(inst, k, optArgs); existing.IsValid() {
+				val.Set(existing)
+			}
 			printLog(optArgs, deep, "Struct>>:", val.String())
 
 			err = valueDeepCopy(val, v, deep+1, "fieldName", optArgs)
@@ -454,6 +534,9 @@ func mapValueDeepCopy(inst reflect.Value, data map[string]interface{}, deep int,
 			}
 		case reflect.Ptr: // map[string]*TestStruct
 			val = reflect.New(inst.Type().Elem().Elem())
+			if existing := mergeMapValue(inst, k, optArgs); existing.IsValid() && !existing.IsNil() {
+				val = existing
+			}
 			printLog(optArgs, deep, "Ptr>>:", val.String())
 
 			err = valueDeepCopy(val.Elem(), v, deep+1, fieldName, optArgs)
@@ -463,6 +546,9 @@ func mapValueDeepCopy(inst reflect.Value, data map[string]interface{}, deep int,
 		case reflect.Map: // map[string]map[string]interface{}
 			if vv, ok := v.(map[string]interface{}); ok {
 				val = reflect.MakeMap(inst.Type().Elem())
+				if existing := mergeMapValue(inst, k, optArgs); existing.IsValid() && !existing.IsNil() {
+					val = existing
+				}
 				printLog(optArgs, deep, "Map>>:", val.String())
 
 				err = mapValueDeepCopy(val, vv, deep+1, fieldName, optArgs)
@@ -474,10 +560,11 @@ func mapValueDeepCopy(inst reflect.Value, data map[string]interface{}, deep int,
 			}
 		case reflect.Slice: // map[string][]interface{}
 			if vv, ok := v.([]interface{}); ok {
-				val = reflect.MakeSlice(inst.Type().Elem(), len(vv), cap(vv))
-				printLog(optArgs, deep, "Slice>>:", val.String())
-
-				err = sliceValueDeepCopy(val, vv, deep+1, fieldName, optArgs)
+				val = reflect.New(inst.Type().Elem()).Elem()
+				if existing := mergeMapValue(inst, k, optArgs); existing.IsValid() {
+					val.Set(existing)
+				}
+				err = valueDeepCopy(val, vv, deep+1, fieldName, optArgs)
 				if err != nil {
 					return
 				}
@@ -491,6 +578,14 @@ func mapValueDeepCopy(inst reflect.Value, data map[string]interface{}, deep int,
 	return
 }
 
+// mergeMapValue 合并模式下返回map中已有的值，否则返回无效值
+func mergeMapValue(inst reflect.Value, key string, optArgs *args) reflect.Value {
+	if optArgs.mergeStrategy == MergeStrategy_Replace {
+		return reflect.Value{}
+	}
+	return inst.MapIndex(reflect.ValueOf(key).Convert(inst.Type().Key()))
+}
+
 func sliceValueDeepCopy(inst reflect.Value, slice []interface{}, deep int, fieldName string, optArgs *args) (err error) {
 	if !inst.IsValid() || inst.Kind() != reflect.Slice {
 		return
@@ -580,7 +675,7 @@ func InstanceToMap(from interface{}, opts ...CopyOption) (out map[string]interfa
 		return nil, errors.New("only process struct/map/slice type")
 	}
 	out = make(map[string]interface{}, numField)
-	err = instanceToMap(out, inst, 0, &optArgs)
+	err = instanceToMap(out, addressableValue(inst), 0, &optArgs)
 	return out, err
 }
 
@@ -594,19 +689,29 @@ func instanceToMap(dest map[string]interface{}, from reflect.Value, deep int, op
 		return instanceToMap(dest, from.Elem(), deep, optArgs)
 	}
 
-	for i := 0; i < from.NumField(); i++ {
-		field := from.Field(i)
-		fieldType := from.Type().Field(i)
-		fieldName, omitempty, ignore := getFieldTag(fieldType, optArgs)
-		if ignore {
+	for _, sf := range structFields(from.Type(), optArgs) {
+		fieldType := sf.fieldType
+		fieldName, omitempty := sf.name, sf.omitempty
+		field, ok := exposeValue(fieldByIndex(from, sf.index))
+		if !ok || !field.IsValid() { // 途经nil的匿名组合指针
 			continue
 		}
 		// 指定需要忽略的字段
 		if _, ok := optArgs.ignoreFieldMap[fieldName]; ok {
 			continue
 		}
-		if field.Kind() == reflect.Ptr {
+		if field.Kind() == reflect.Ptr && !field.IsNil() {
 			field = field.Elem()
+			if optArgs.keepZeroPtr {
+				omitempty = false
+			}
+		}
+		// nil指针/interface -> nil, nil的map/slice按 WithNilAsEmpty 输出; omitempty时忽略
+		if val, isNil := nilToMapValue(field, optArgs); isNil {
+			if !omitempty && !sf.inlineMap {
+				dest[fieldName] = val
+			}
+			continue
 		}
 		printLog(optArgs, deep, "kind:", field.Kind(), "fieldName:", fieldName, "value:", field.Interface(), "omitempty:", omitempty, "anonymous", fieldType.Anonymous)
 
@@ -625,11 +730,16 @@ func instanceToMap(dest map[string]interface{}, from reflect.Value, deep int, op
 				}
 				continue
 			}
-			subMap := dest
-			if !fieldType.Anonymous {
-				subMap = make(map[string]interface{}, field.NumField())
-				dest[fieldName] = subMap
+			if val, ok := bigToValue(field); ok {
+				if omitempty && field.IsZero() {
+					continue
+				}
+				dest[fieldName] = val
+				continue
 			}
+			// 需要展开的匿名组合已由 structFields 处理
+			subMap := make(map[string]interface{}, field.NumField())
+			dest[fieldName] = subMap
 			if err = instanceToMap(subMap, field, deep+1, optArgs); err != nil {
 				return
 			}
@@ -639,7 +749,7 @@ func instanceToMap(dest map[string]interface{}, from reflect.Value, deep int, op
 				continue
 			}
 			subMap := dest
-			if !fieldType.Anonymous {
+			if !sf.inlineMap {
 				subMap = make(map[string]interface{}, len(keys))
 				dest[fieldName] = subMap
 			}
@@ -676,12 +786,20 @@ func instanceMapToMap(dest map[string]interface{}, field reflect.Value, deep int
 	for _, key := range keys {
 		keyStr := interface2String(key.Interface())
 		subField := field.MapIndex(key)
-		if subField.Kind() == reflect.Ptr {
+		if subField.Kind() == reflect.Ptr && !subField.IsNil() {
 			subField = subField.Elem()
 		}
+		if val, isNil := nilToMapValue(subField, optArgs); isNil {
+			dest[keyStr] = val
+			continue
+		}
 		printLog(optArgs, deep, "kind:", subField.Kind(), "key:", keyStr, "value:", subField.Interface())
 		switch subField.Kind() {
 		case reflect.Struct:
+			if val, ok := bigToValue(subField); ok {
+				dest[keyStr] = val
+				continue
+			}
 			subMap := make(map[string]interface{}, subField.NumField())
 			dest[keyStr] = subMap
 			if err := instanceToMap(subMap, subField, deep+1, optArgs); err != nil {
@@ -714,12 +832,20 @@ func instanceSliceToArr(dest []interface{}, field reflect.Value, deep int, optAr
 
 	for i := 0; i < field.Len(); i++ {
 		item := field.Index(i)
-		if item.Kind() == reflect.Ptr {
+		if item.Kind() == reflect.Ptr && !item.IsNil() {
 			item = item.Elem()
 		}
+		if val, isNil := nilToMapValue(item, optArgs); isNil {
+			dest[i] = val
+			continue
+		}
 		printLog(optArgs, deep, "kind:", item.Kind(), "index:", i, "value:", item.Interface())
 		switch item.Kind() {
 		case reflect.Struct:
+			if val, ok := bigToValue(item); ok {
+				dest[i] = val
+				continue
+			}
 			subMap := make(map[string]interface{}, item.NumField())
 			dest[i] = subMap
 			if err := instanceToMap(subMap, item, deep+1, optArgs); err != nil {
@@ -745,6 +871,32 @@ func instanceSliceToArr(dest []interface{}, field reflect.Value, deep int, optAr
 	return nil
 }
 
+// nilToMapValue nil指针/interface输出nil，nil的map/slice按 WithNilAsEmpty 输出
+// 第二个返回值表示是否为nil
+func nilToMapValue(field reflect.Value, optArgs *args) (interface{}, bool) {
+	switch field.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return nil, field.IsNil()
+	case reflect.Map:
+		if !field.IsNil() {
+			return nil, false
+		}
+		if optArgs.nilAsEmpty {
+			return map[string]interface{}{}, true
+		}
+		return nil, true
+	case reflect.Slice:
+		if !field.IsNil() {
+			return nil, false
+		}
+		if optArgs.nilAsEmpty {
+			return []interface{}{}, true
+		}
+		return nil, true
+	}
+	return nil, false
+}
+
 func valueEmpty(v interface{}) bool {
 	// 自定义类型，需要查看基础类型
 	t := reflect.TypeOf(v)
@@ -314,4 +314,151 @@ func Test_getFieldTag(t *testing.T) {
 		t.Logf("getFieldTag() gotIgnore = %v", gotIgnore)
 	}
 
-}
\ No newline at end of file
+}
+
+type mergeDB struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+type mergeConfig struct {
+	Name  string                  `json:"name"`
+	DB    *mergeDB                `json:"db"`
+	Attrs map[string]string       `json:"attrs"`
+	Nodes map[string]*InnerStruct `json:"nodes"`
+	Tags  []string                `json:"tags"`
+}
+
+func newMergeConfig() *mergeConfig {
+	return &mergeConfig{
+		Name:  "default",
+		DB:    &mergeDB{Host: "localhost", Port: 3306},
+		Attrs: map[string]string{"a": "1", "b": "2"},
+		Nodes: map[string]*InnerStruct{"n1": {A: 1, B: "b1"}},
+		Tags:  []string{"x"},
+	}
+}
+
+func TestInstanceFromMapMerge(t *testing.T) {
+	patch := map[string]interface{}{
+		"db":    map[string]interface{}{"port": "3307"},
+		"attrs": map[string]interface{}{"b": "3", "c": 4},
+		"nodes": map[string]interface{}{"n1": map[string]interface{}{"aa": 2}},
+		"tags":  []interface{}{"y"},
+	}
+	tests := []struct {
+		name     string
+		strategy MergeStrategy
+		want     *mergeConfig
+	}{
+		{
+			name:     "Replace",
+			strategy: MergeStrategy_Replace,
+			want: &mergeConfig{
+				Name:  "default",
+				DB:    &mergeDB{Port: 3307},
+				Attrs: map[string]string{"b": "3", "c": "4"},
+				Nodes: map[string]*InnerStruct{"n1": {A: 2}},
+				Tags:  []string{"y"},
+			},
+		},
+		{
+			name:     "Merge",
+			strategy: MergeStrategy_Merge,
+			want: &mergeConfig{
+				Name:  "default",
+				DB:    &mergeDB{Host: "localhost", Port: 3307},
+				Attrs: map[string]string{"a": "1", "b": "3", "c": "4"},
+				Nodes: map[string]*InnerStruct{"n1": {A: 2, B: "b1"}},
+				Tags:  []string{"y"},
+			},
+		},
+		{
+			name:     "AppendSlices",
+			strategy: MergeStrategy_AppendSlices,
+			want: &mergeConfig{
+				Name:  "default",
+				DB:    &mergeDB{Host: "localhost", Port: 3307},
+				Attrs: map[string]string{"a": "1", "b": "3", "c": "4"},
+				Nodes: map[string]*InnerStruct{"n1": {A: 2, B: "b1"}},
+				Tags:  []string{"x", "y"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newMergeConfig()
+			if err := InstanceFromMap(got, patch, WithMergeStrategy(tt.strategy)); err != nil {
+				t.Fatalf("InstanceFromMap() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("InstanceFromMap() = %+v, want %+v", got, tt.want)
+			}
+
+			bytes, _ := json.Marshal(patch)
+			streamed := newMergeConfig()
+			if err := InstanceFromBytes(streamed, bytes, WithMergeStrategy(tt.strategy)); err != nil {
+				t.Fatalf("InstanceFromBytes() error = %v", err)
+			}
+			if !reflect.DeepEqual(streamed, tt.want) {
+				t.Errorf("InstanceFromBytes() = %+v, want %+v", streamed, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstanceToMapNil(t *testing.T) {
+	type Item struct {
+		Name string `json:"name"`
+	}
+	type Obj struct {
+		Ptr   *Item             `json:"ptr"`
+		Num   *int              `json:"num"`
+		Tags  []string          `json:"tags"`
+		Attrs map[string]string `json:"attrs"`
+		Any   interface{}       `json:"any"`
+		Items []*Item           `json:"items"`
+	}
+	zero := 0
+	tests := []struct {
+		name string
+		from Obj
+		opts []CopyOption
+		want map[string]interface{}
+	}{
+		{
+			name: "nil",
+			from: Obj{Items: []*Item{nil}},
+			want: map[string]interface{}{"ptr": nil, "num": nil, "tags": nil, "attrs": nil, "any": nil, "items": []interface{}{nil}},
+		},
+		{
+			name: "nil_as_empty",
+			from: Obj{},
+			opts: []CopyOption{WithNilAsEmpty(true)},
+			want: map[string]interface{}{"ptr": nil, "num": nil, "tags": []interface{}{}, "attrs": map[string]interface{}{}, "any": nil, "items": []interface{}{}},
+		},
+		{
+			name: "omitempty",
+			from: Obj{Num: &zero},
+			opts: []CopyOption{WithFieldType(FieldType_Gorm), WithOmitempty(true)},
+			want: map[string]interface{}{},
+		},
+		{
+			name: "keep_zero_ptr",
+			from: Obj{Num: &zero},
+			opts: []CopyOption{WithFieldType(FieldType_Gorm), WithOmitempty(true), WithKeepZeroPtr(true)},
+			want: map[string]interface{}{"num": int64(0)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InstanceToMap(tt.from, tt.opts...)
+			if err != nil {
+				t.Fatalf("InstanceToMap() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("InstanceToMap() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,412 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: diff.go
+ * @time: 2026/10/20 09:30
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ChangeOp 差异的类型
+type ChangeOp int8
+
+const (
+	ChangeOp_Added    ChangeOp = 1 + iota // b中新增
+	ChangeOp_Removed                      // b中删除
+	ChangeOp_Modified                     // 值发生变化
+)
+
+func (op ChangeOp) String() string {
+	switch op {
+	case ChangeOp_Added:
+		return "added"
+	case ChangeOp_Removed:
+		return "removed"
+	case ChangeOp_Modified:
+		return "modified"
+	}
+	return "unknown"
+}
+
+// Change 一处差异
+type Change struct {
+	Path string      // 按tag字段名拼接的路径，可用于 GetFieldValue，如 profile.addresses[0].city, attrs["a.b"]
+	Op   ChangeOp    // 差异类型
+	Old  interface{} // a中的值，按 InstanceToMap 的规则转换；Added时为nil
+	New  interface{} // b中的值，按 InstanceToMap 的规则转换；Removed时为nil
+}
+
+// Diff 比较同类型的a和b，返回b相对a的差异
+// 递归比较结构体、map、slice和time.Time，字段名按 WithFieldType 选择的tag获取
+// WithIgnoreFields 可以是go字段名/tag字段名/字段路径(同 StructCopy)，也可以是差异的路径
+// slice默认按下标比较；字段的tag为 dcopy:",key=ID" 时按元素的ID字段(字段名或tag)匹配，路径中的下标为元素在a或b中的位置
+// a和b可以是值或指针，nil与空的map/slice视为相同；类型不同时在根路径返回一个 Modified
+func Diff(a, b interface{}, opts ...CopyOption) []Change {
+	optArgs := newOpts(opts...)
+	d := &differ{optArgs: &optArgs, nilAsEmpty: true, timeEqual: true, numericEqual: true}
+	d.diff(reflect.ValueOf(a), reflect.ValueOf(b), "", "")
+	return d.changes
+}
+
+type differ struct {
+	optArgs      *args
+	nilAsEmpty   bool   // nil与空的map/slice视为相同
+	timeEqual    bool   // time.Time 只比较时间点，否则还要求时区一致
+	numericEqual bool   // 不同类型的数字按数值比较
+	fieldPath    string // 当前结构体按go字段名拼接的路径，用于匹配 WithIgnoreFields
+	changes      []Change
+}
+
+func (d *differ) add(path string, op ChangeOp, from, to reflect.Value) {
+	change := Change{Path: path, Op: op}
+	if op != ChangeOp_Added {
+		change.Old = d.generic(from)
+	}
+	if op != ChangeOp_Removed {
+		change.New = d.generic(to)
+	}
+	d.changes = append(d.changes, change)
+}
+
+// generic 转换成 InstanceToMap 输出的通用数据
+func (d *differ) generic(v reflect.Value) interface{} {
+	out, err := toGenericValue(v, d.optArgs)
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+// diff key为slice按元素匹配时使用的字段
+func (d *differ) diff(from, to reflect.Value, path, key string) {
+	from, to = addressableValue(d.indirect(from)), addressableValue(d.indirect(to))
+	switch {
+	case !from.IsValid() && !to.IsValid():
+		return
+	case !from.IsValid():
+		d.add(path, ChangeOp_Added, from, to)
+		return
+	case !to.IsValid():
+		d.add(path, ChangeOp_Removed, from, to)
+		return
+	}
+
+	fromKind, toKind := from.Kind(), to.Kind()
+	if from.Type() != to.Type() {
+		switch {
+		case isListKind(fromKind) && isListKind(toKind), fromKind == reflect.Map && toKind == reflect.Map:
+			// 不同类型的slice/map逐个元素比较
+		case isStructMap(from, to):
+			d.diffStructMap(from, to, path, true)
+			return
+		case isStructMap(to, from):
+			d.diffStructMap(to, from, path, false)
+			return
+		default:
+			if !d.scalarEqual(from, to) {
+				d.add(path, ChangeOp_Modified, from, to)
+			}
+			return
+		}
+	}
+
+	switch fromKind {
+	case reflect.Struct:
+		if t, ok := from.Interface().(time.Time); ok {
+			u := to.Interface().(time.Time)
+			if !t.Equal(u) || (!d.timeEqual && t.Location().String() != u.Location().String()) {
+				d.add(path, ChangeOp_Modified, from, to)
+			}
+			return
+		}
+		if isBigType(from.Type()) {
+			if !reflect.DeepEqual(d.generic(from), d.generic(to)) {
+				d.add(path, ChangeOp_Modified, from, to)
+			}
+			return
+		}
+		d.diffStruct(from, to, path)
+	case reflect.Map:
+		d.diffMap(from, to, path)
+	case reflect.Slice, reflect.Array:
+		if key != "" {
+			d.diffKeyed(from, to, path, key)
+			return
+		}
+		for i := 0; i < from.Len() || i < to.Len(); i++ {
+			elemPath := indexPath(path, i)
+			switch {
+			case i >= to.Len():
+				d.add(elemPath, ChangeOp_Removed, from.Index(i), reflect.Value{})
+			case i >= from.Len():
+				d.add(elemPath, ChangeOp_Added, reflect.Value{}, to.Index(i))
+			default:
+				d.diff(from.Index(i), to.Index(i), elemPath, "")
+			}
+		}
+	default:
+		if !reflect.DeepEqual(from.Interface(), to.Interface()) {
+			d.add(path, ChangeOp_Modified, from, to)
+		}
+	}
+}
+
+// scalarEqual 不同类型的值是否相同
+// numericEqual时数字按数值比较(如 int 和 float64, json.Number)，time.Time 按 InstanceToMap 的格式与字符串/时间戳比较
+func (d *differ) scalarEqual(from, to reflect.Value) bool {
+	if !d.numericEqual {
+		return false
+	}
+	if _, ok := from.Interface().(time.Time); ok {
+		return jsonEqual(d.generic(from), to.Interface())
+	}
+	if _, ok := to.Interface().(time.Time); ok {
+		return jsonEqual(from.Interface(), d.generic(to))
+	}
+	return isNumberKind(from) && isNumberKind(to) && numberEqual(from.Interface(), to.Interface())
+}
+
+func (d *differ) diffStruct(from, to reflect.Value, path string) {
+	fieldPath := d.fieldPath
+	defer func() { d.fieldPath = fieldPath }()
+	for _, sf := range structFields(from.Type(), d.optArgs) {
+		if d.ignoreField(sf, path, fieldPath) {
+			continue
+		}
+		fromField, ok := exposeValue(fieldByIndex(from, sf.index))
+		if !ok {
+			continue
+		}
+		toField, ok := exposeValue(fieldByIndex(to, sf.index))
+		if !ok {
+			continue
+		}
+		d.fieldPath = joinFieldPath(fieldPath, sf.fieldType.Name)
+		d.diff(fromField, toField, keyPath(path, sf.name), diffKeyField(sf.fieldType))
+	}
+}
+
+// ignoreField 字段是否被 WithIgnoreFields 忽略，匹配规则同 StructCopy，另外支持差异路径(tag字段名拼接)
+func (d *differ) ignoreField(sf structField, path, fieldPath string) bool {
+	names := append(fieldMatchNames(sf.fieldType, sf.name, fieldPath), strings.ToLower(keyPath(path, sf.name)))
+	return containsFieldName(d.optArgs.ignoreFieldMap, names)
+}
+
+func (d *differ) diffMap(from, to reflect.Value, path string) {
+	fromKeys, toKeys := mapKeysByName(from), mapKeysByName(to)
+	names := make([]string, 0, len(fromKeys)+len(toKeys))
+	for name := range fromKeys {
+		names = append(names, name)
+	}
+	for name := range toKeys {
+		if _, ok := fromKeys[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var fromVal, toVal reflect.Value
+		if k, ok := fromKeys[name]; ok {
+			fromVal = from.MapIndex(k)
+		}
+		if k, ok := toKeys[name]; ok {
+			toVal = to.MapIndex(k)
+		}
+		d.diff(fromVal, toVal, keyPath(path, name), "")
+	}
+}
+
+// diffStructMap 按tag字段名比较结构体和 map[string]T
+// map中缺少的key与0值字段视为相同；structFrom表示结构体是否为比较的a
+func (d *differ) diffStructMap(st, mp reflect.Value, path string, structFrom bool) {
+	keys := mapKeysByName(mp)
+	pair := func(field, val reflect.Value, fieldPath string) {
+		if structFrom {
+			d.diff(field, val, fieldPath, "")
+		} else {
+			d.diff(val, field, fieldPath, "")
+		}
+	}
+	known := make(map[string]bool, len(keys))
+	fieldPath := d.fieldPath
+	defer func() { d.fieldPath = fieldPath }()
+	for _, sf := range structFields(st.Type(), d.optArgs) {
+		if d.ignoreField(sf, path, fieldPath) {
+			known[sf.name] = true
+			continue
+		}
+		field, ok := exposeValue(fieldByIndex(st, sf.index))
+		if !ok {
+			continue
+		}
+		known[sf.name] = true
+		d.fieldPath = joinFieldPath(fieldPath, sf.fieldType.Name)
+		k, ok := keys[sf.name]
+		if !ok {
+			if field.IsValid() && !field.IsZero() {
+				pair(field, reflect.Value{}, keyPath(path, sf.name))
+			}
+			continue
+		}
+		pair(field, mp.MapIndex(k), keyPath(path, sf.name))
+	}
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		if !known[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		pair(reflect.Value{}, mp.MapIndex(keys[name]), keyPath(path, name))
+	}
+}
+
+// mapKeysByName key按字符串索引
+func mapKeysByName(mp reflect.Value) map[string]reflect.Value {
+	out := make(map[string]reflect.Value, mp.Len())
+	for _, k := range mp.MapKeys() {
+		out[interface2String(k.Interface())] = k
+	}
+	return out
+}
+
+// diffKeyed 按key字段匹配slice的元素
+func (d *differ) diffKeyed(from, to reflect.Value, path, key string) {
+	fromIndex := make(map[string]int, from.Len())
+	for i := 0; i < from.Len(); i++ {
+		if k, ok := d.elemKey(from.Index(i), key); ok {
+			if _, dup := fromIndex[k]; !dup {
+				fromIndex[k] = i
+			}
+		}
+	}
+	matched := make(map[int]bool, from.Len())
+	for i := 0; i < to.Len(); i++ {
+		k, ok := d.elemKey(to.Index(i), key)
+		j, found := fromIndex[k]
+		if ok && found && !matched[j] {
+			matched[j] = true
+			d.diff(from.Index(j), to.Index(i), indexPath(path, i), "")
+			continue
+		}
+		d.add(indexPath(path, i), ChangeOp_Added, reflect.Value{}, to.Index(i))
+	}
+	for i := 0; i < from.Len(); i++ {
+		if !matched[i] {
+			d.add(indexPath(path, i), ChangeOp_Removed, from.Index(i), reflect.Value{})
+		}
+	}
+}
+
+// elemKey 获取slice元素的key字段值
+func (d *differ) elemKey(elem reflect.Value, key string) (string, bool) {
+	elem = d.indirect(elem)
+	if elem.Kind() != reflect.Struct {
+		return "", false
+	}
+	field, ok := pathWalker{optArgs: d.optArgs}.field(addressableValue(elem), key, false)
+	if !ok || !field.CanInterface() {
+		return "", false
+	}
+	return interface2String(field.Interface()), true
+}
+
+// indirect 去掉指针和interface，nil以及nil的map/slice返回无效值
+// nilAsEmpty时空的map/slice同样返回无效值
+func (d *differ) indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	if v.IsValid() && (v.Kind() == reflect.Map || v.Kind() == reflect.Slice) {
+		if v.IsNil() || (d.nilAsEmpty && v.Len() == 0) {
+			return reflect.Value{}
+		}
+	}
+	return v
+}
+
+// isListKind slice或array
+func isListKind(kind reflect.Kind) bool {
+	return kind == reflect.Slice || kind == reflect.Array
+}
+
+// isStructMap st是结构体，mp是key为字符串的map
+func isStructMap(st, mp reflect.Value) bool {
+	return st.Kind() == reflect.Struct && isNestedStruct(st.Type()) &&
+		mp.Kind() == reflect.Map && mp.Type().Key().Kind() == reflect.String
+}
+
+// isNumberKind 整数、浮点数或 json.Number
+func isNumberKind(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return v.Type() == reflect.TypeOf(json.Number(""))
+}
+
+// numberEqual 按数值比较，浮点数按最短的十进制表示
+func numberEqual(a, b interface{}) bool {
+	x, ok1 := new(big.Float).SetPrec(256).SetString(numberString(a))
+	y, ok2 := new(big.Float).SetPrec(256).SetString(numberString(b))
+	return ok1 && ok2 && x.Cmp(y) == 0
+}
+
+func numberString(v interface{}) string {
+	switch n := getBasicValue(v).(type) {
+	case int64:
+		return strconv.FormatInt(n, 10)
+	case uint64:
+		return strconv.FormatUint(n, 10)
+	case float64:
+		return strconv.FormatFloat(n, 'g', -1, 64)
+	case string:
+		return n
+	}
+	return ""
+}
+
+// diffKeyField slice字段按元素匹配时使用的字段，如 dcopy:",key=ID"
+func diffKeyField(fieldType reflect.StructField) string {
+	arr := strings.Split(fieldType.Tag.Get("dcopy"), ",")
+	for _, it := range arr[1:] {
+		if key := strings.TrimSpace(it); strings.HasPrefix(key, "key=") {
+			return strings.TrimPrefix(key, "key=")
+		}
+	}
+	return ""
+}
+
+// keyPath 拼接字段名或map的key，包含特殊字符时写成 ["key"]
+func keyPath(path, key string) string {
+	if key == "" || strings.ContainsAny(key, `.[]"'`) {
+		return path + "[" + strconv.Quote(key) + "]"
+	}
+	return joinFieldPath(path, key)
+}
+
+func indexPath(path string, i int) string {
+	return fmt.Sprintf("%s[%d]", path, i)
+}
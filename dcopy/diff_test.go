@@ -0,0 +1,166 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: diff_test.go
+ * @time: 2026/10/20 09:30
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type diffLine struct {
+	ID  int64  `json:"id"`
+	Sku string `json:"sku"`
+	Qty int    `json:"qty"`
+}
+
+type diffOrder struct {
+	Name      string            `json:"name"`
+	Lines     []diffLine        `json:"lines" dcopy:",key=ID"`
+	Tags      []string          `json:"tags"`
+	Attrs     map[string]string `json:"attrs"`
+	Owner     *diffLine         `json:"owner"`
+	Extra     interface{}       `json:"extra"`
+	Created   time.Time         `json:"created"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	internal  int
+}
+
+func TestDiff(t *testing.T) {
+	created := time.Date(2026, 10, 19, 8, 0, 0, 0, time.Local)
+	base := func() diffOrder {
+		return diffOrder{
+			Name:    "o1",
+			Lines:   []diffLine{{ID: 1, Sku: "a", Qty: 1}, {ID: 2, Sku: "b", Qty: 2}},
+			Tags:    []string{"x", "y"},
+			Attrs:   map[string]string{"k": "v", "a.b": "1"},
+			Extra:   1,
+			Created: created,
+		}
+	}
+	tests := []struct {
+		name   string
+		modify func(o *diffOrder)
+		opts   []CopyOption
+		want   []Change
+	}{
+		{
+			name:   "TestDiff_equal",
+			modify: func(o *diffOrder) { o.internal = 1; o.Attrs = map[string]string{"a.b": "1", "k": "v"} },
+			want:   nil,
+		},
+		{
+			name:   "TestDiff_field",
+			modify: func(o *diffOrder) { o.Name = "o2" },
+			want:   []Change{{Path: "name", Op: ChangeOp_Modified, Old: "o1", New: "o2"}},
+		},
+		{
+			name: "TestDiff_keyed",
+			modify: func(o *diffOrder) {
+				o.Lines = []diffLine{{ID: 3, Sku: "c"}, {ID: 2, Sku: "b", Qty: 5}}
+			},
+			want: []Change{
+				{Path: "lines[0]", Op: ChangeOp_Added, New: map[string]interface{}{"id": int64(3), "sku": "c", "qty": int64(0)}},
+				{Path: "lines[1].qty", Op: ChangeOp_Modified, Old: int64(2), New: int64(5)},
+				{Path: "lines[0]", Op: ChangeOp_Removed, Old: map[string]interface{}{"id": int64(1), "sku": "a", "qty": int64(1)}},
+			},
+		},
+		{
+			name:   "TestDiff_index",
+			modify: func(o *diffOrder) { o.Tags = []string{"x", "z", "w"} },
+			want: []Change{
+				{Path: "tags[1]", Op: ChangeOp_Modified, Old: "y", New: "z"},
+				{Path: "tags[2]", Op: ChangeOp_Added, New: "w"},
+			},
+		},
+		{
+			name:   "TestDiff_map",
+			modify: func(o *diffOrder) { o.Attrs = map[string]string{"k": "v2", "n": "1"} },
+			want: []Change{
+				{Path: `attrs["a.b"]`, Op: ChangeOp_Removed, Old: "1"},
+				{Path: "attrs.k", Op: ChangeOp_Modified, Old: "v", New: "v2"},
+				{Path: "attrs.n", Op: ChangeOp_Added, New: "1"},
+			},
+		},
+		{
+			name:   "TestDiff_ptr",
+			modify: func(o *diffOrder) { o.Owner = &diffLine{ID: 9} },
+			want: []Change{
+				{Path: "owner", Op: ChangeOp_Added, New: map[string]interface{}{"id": int64(9), "sku": "", "qty": int64(0)}},
+			},
+		},
+		{
+			name:   "TestDiff_interfaceType",
+			modify: func(o *diffOrder) { o.Extra = "1" },
+			want:   []Change{{Path: "extra", Op: ChangeOp_Modified, Old: int64(1), New: "1"}},
+		},
+		{
+			name:   "TestDiff_time",
+			modify: func(o *diffOrder) { o.Created = created.Add(time.Hour) },
+			opts:   []CopyOption{WithTimeValType(TimeValType_Int64)},
+			want:   []Change{{Path: "created", Op: ChangeOp_Modified, Old: created.Unix(), New: created.Unix() + 3600}},
+		},
+		{
+			name:   "TestDiff_emptySlice",
+			modify: func(o *diffOrder) { o.Tags = nil; o.Lines = o.Lines[:0] },
+			want: []Change{
+				{Path: "lines", Op: ChangeOp_Removed, Old: []interface{}{
+					map[string]interface{}{"id": int64(1), "sku": "a", "qty": int64(1)},
+					map[string]interface{}{"id": int64(2), "sku": "b", "qty": int64(2)},
+				}},
+				{Path: "tags", Op: ChangeOp_Removed, Old: []interface{}{"x", "y"}},
+			},
+		},
+		{
+			name:   "TestDiff_ignore",
+			modify: func(o *diffOrder) { o.Name = "o2" },
+			opts:   []CopyOption{WithIgnoreFields("Name")},
+			want:   nil,
+		},
+		{
+			name:   "TestDiff_ignoreGoName",
+			modify: func(o *diffOrder) { o.UpdatedAt = created; o.Name = "o2" },
+			opts:   []CopyOption{WithIgnoreFields("UpdatedAt")},
+			want:   []Change{{Path: "name", Op: ChangeOp_Modified, Old: "o1", New: "o2"}},
+		},
+		{
+			name:   "TestDiff_ignorePath",
+			modify: func(o *diffOrder) { o.Lines[0].Qty = 5; o.Lines[0].Sku = "z"; o.Lines[1].Qty = 6 },
+			opts:   []CopyOption{WithIgnoreFields("Lines.Qty")},
+			want:   []Change{{Path: "lines[0].sku", Op: ChangeOp_Modified, Old: "a", New: "z"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := base(), base()
+			tt.modify(&b)
+			got := Diff(a, &b, tt.opts...)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffPathResolvable(t *testing.T) {
+	a := diffOrder{Attrs: map[string]string{"a.b": "1"}, Lines: []diffLine{{ID: 1}}}
+	b := diffOrder{Attrs: map[string]string{"a.b": "2"}, Lines: []diffLine{{ID: 1, Qty: 3}}}
+	for _, change := range Diff(a, b) {
+		if got := GetFieldValue(b, change.Path); !reflect.DeepEqual(getBasicValue(got), change.New) {
+			t.Errorf("GetFieldValue(%s) = %v, want %v", change.Path, got, change.New)
+		}
+	}
+	if got := Diff(a, 1); len(got) != 1 || got[0].Op != ChangeOp_Modified {
+		t.Errorf("Diff() different types = %v", got)
+	}
+}
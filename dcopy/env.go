@@ -0,0 +1,219 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: env.go
+ * @time: 2026/10/20 18:10
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// WithEnvSeparator InstanceFromEnv 时slice类型字段的分隔符，默认为 ,
+func WithEnvSeparator(sep string) CopyOption {
+	return func(a *args) {
+		a.envSep = sep
+	}
+}
+
+// InstanceFromEnv 读取进程的环境变量到dest，dest必须为结构体指针
+// 变量名为 前缀_字段名，字段名由go字段名转为大写下划线，嵌套结构体逐级拼接，如 APP_DB_HOST -> DB.Host
+// tag: env:"NAME" 指定字段名，env:"-" 忽略，env:",required" 变量不存在且没有默认值时报错，default:"x" 变量不存在时的默认值
+// slice/数组按 WithEnvSeparator 分隔(超出数组长度的部分忽略)，time.Duration(包括元素)按 time.ParseDuration 解析，其余值按 InstanceFromMap 的规则转换
+func InstanceFromEnv(dest interface{}, prefix string, opts ...CopyOption) error {
+	return InstanceFromEnviron(dest, prefix, os.Environ(), opts...)
+}
+
+// InstanceFromEnviron 同 InstanceFromEnv，环境变量从environ读取，格式同 os.Environ
+func InstanceFromEnviron(dest interface{}, prefix string, environ []string, opts ...CopyOption) (err error) {
+	inst := reflect.ValueOf(dest)
+	if inst.Kind() != reflect.Ptr || inst.IsNil() || inst.Elem().Kind() != reflect.Struct {
+		return errors.New("not struct pointer target")
+	}
+	optArgs := newOpts(opts...)
+	if optArgs.envSep == "" {
+		optArgs.envSep = ","
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("instance from env err=[%v]", r)
+			printLog(&optArgs, 0, r)
+		}
+	}()
+
+	b := &envBinder{vars: make(map[string]string, len(environ)), optArgs: &optArgs, binding: map[reflect.Type]bool{}}
+	for _, it := range environ {
+		if idx := strings.IndexByte(it, '='); idx > 0 {
+			b.vars[it[:idx]] = it[idx+1:]
+		}
+	}
+	if _, err := b.bind(inst.Elem(), strings.TrimSuffix(prefix, "_")); err != nil {
+		return err
+	}
+	if len(b.missing) > 0 {
+		return fmt.Errorf("required env not set: %s", strings.Join(b.missing, ", "))
+	}
+	return nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// envBinder 按环境变量填充结构体
+type envBinder struct {
+	vars    map[string]string
+	optArgs *args
+	missing []string              // 未设置的必填变量
+	binding map[reflect.Type]bool // 当前路径上正在填充的结构体类型，避免递归类型无限展开
+}
+
+// bind 填充inst的导出字段，返回是否读取到了环境变量(默认值不算)
+// 递归类型(如 type Node struct{ Child *Node })中已在当前路径上的结构体不再展开
+func (b *envBinder) bind(inst reflect.Value, prefix string) (bool, error) {
+	if b.binding[inst.Type()] {
+		return false, nil
+	}
+	b.binding[inst.Type()] = true
+	defer delete(b.binding, inst.Type())
+	set := false
+	for i := 0; i < inst.NumField(); i++ {
+		fieldType := inst.Type().Field(i)
+		// 未导出类型的嵌入结构体仍然展开它的导出字段
+		if !fieldType.IsExported() && !(fieldType.Anonymous && fieldType.Type.Kind() == reflect.Struct) {
+			continue
+		}
+		tag := fieldType.Tag.Get("env")
+		if tag == "-" {
+			continue
+		}
+		name, opt, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = screamingSnakeCase(fieldType.Name)
+		}
+		field := inst.Field(i)
+		if isEnvStruct(fieldType.Type) {
+			fieldPrefix := joinEnvName(prefix, name)
+			if fieldType.Anonymous && tag == "" {
+				fieldPrefix = prefix
+			}
+			ok, err := b.bindStruct(field, fieldPrefix)
+			if err != nil {
+				return false, err
+			}
+			set = set || ok
+			continue
+		}
+
+		key := joinEnvName(prefix, name)
+		val, found := b.vars[key]
+		ok := found
+		if !ok {
+			val, ok = fieldType.Tag.Lookup("default")
+		}
+		if !ok {
+			if opt == "required" {
+				b.missing = append(b.missing, key)
+			}
+			continue
+		}
+		if err := b.set(field, val, key); err != nil {
+			return false, fmt.Errorf("env %s: %v", key, err)
+		}
+		set = set || found
+	}
+	return set, nil
+}
+
+// bindStruct 填充嵌套结构体，nil指针只在读取到环境变量时才分配
+func (b *envBinder) bindStruct(field reflect.Value, prefix string) (bool, error) {
+	if field.Kind() != reflect.Ptr {
+		return b.bind(field, prefix)
+	}
+	if !field.IsNil() {
+		return b.bind(field.Elem(), prefix)
+	}
+	it := reflect.New(field.Type().Elem())
+	ok, err := b.bind(it.Elem(), prefix)
+	if ok && err == nil {
+		field.Set(it)
+	}
+	return ok, err
+}
+
+// set 把变量的值写入field，slice按分隔符拆分
+func (b *envBinder) set(field reflect.Value, val, key string) error {
+	tpe := field.Type()
+	for tpe.Kind() == reflect.Ptr {
+		tpe = tpe.Elem()
+	}
+	if (tpe.Kind() == reflect.Slice || tpe.Kind() == reflect.Array) && tpe.Elem().Kind() != reflect.Uint8 {
+		var parts []string
+		if strings.TrimSpace(val) != "" {
+			parts = strings.Split(val, b.optArgs.envSep)
+		}
+		// 按元素类型逐个写入，time.Duration 等解析后的值不再经过通用转换
+		list := reflect.New(tpe).Elem()
+		if tpe.Kind() == reflect.Slice {
+			list = reflect.MakeSlice(tpe, len(parts), len(parts))
+		}
+		for i := 0; i < len(parts) && i < list.Len(); i++ {
+			item, err := envValue(tpe.Elem(), strings.TrimSpace(parts[i]), b.optArgs)
+			if err != nil {
+				return err
+			}
+			if err := assignValue(list.Index(i), item, key, b.optArgs); err != nil {
+				return err
+			}
+		}
+		return assignValue(field, list.Interface(), key, b.optArgs)
+	}
+	if tpe.Kind() == reflect.Slice {
+		return assignValue(field, []byte(val), key, b.optArgs)
+	}
+	item, err := envValue(tpe, val, b.optArgs)
+	if err != nil {
+		return err
+	}
+	return assignValue(field, item, key, b.optArgs)
+}
+
+// envValue 转换单个值，time.Duration 按 time.ParseDuration 解析，其余检查能否转换
+func envValue(tpe reflect.Type, val string, optArgs *args) (interface{}, error) {
+	for tpe.Kind() == reflect.Ptr {
+		tpe = tpe.Elem()
+	}
+	if tpe == durationType {
+		return time.ParseDuration(val)
+	}
+	if err := checkConvertible(tpe, val, optArgs); err != nil {
+		return nil, err
+	}
+	return val, nil
+}
+
+// isEnvStruct 是否按嵌套结构体展开，time.Time 和大数类型按单个值处理
+func isEnvStruct(tpe reflect.Type) bool {
+	for tpe.Kind() == reflect.Ptr {
+		tpe = tpe.Elem()
+	}
+	return tpe.Kind() == reflect.Struct && tpe != reflect.TypeOf(time.Time{}) && !isBigType(tpe)
+}
+
+// joinEnvName 拼接环境变量名
+func joinEnvName(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "_" + name
+}
@@ -0,0 +1,199 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: env_test.go
+ * @time: 2026/10/20 18:10
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type envDB struct {
+	Host    string        `default:"localhost"`
+	Port    int           `default:"3306"`
+	Timeout time.Duration `default:"5s"`
+}
+
+type envLog struct {
+	Level string `env:"LEVEL"`
+}
+
+type envCommon struct {
+	Debug bool
+}
+
+type envConfig struct {
+	envCommon
+	Name     string `env:",required"`
+	DB       envDB
+	Cache    *envDB `env:"REDIS"`
+	Log      *envLog
+	Hosts    []string
+	Ports    []int
+	Delays   []time.Duration
+	Backoff  [2]time.Duration
+	UserID   int64
+	Secret   string `env:"-"`
+	internal string
+}
+
+type envNode struct {
+	Name  string
+	Child *envNode
+}
+
+func TestScreamingSnakeCase(t *testing.T) {
+	tests := []struct {
+		name string
+		str  string
+		want string
+	}{
+		{name: "TestScreamingSnakeCase_simple", str: "Host", want: "HOST"},
+		{name: "TestScreamingSnakeCase_camel", str: "MaxIdleConns", want: "MAX_IDLE_CONNS"},
+		{name: "TestScreamingSnakeCase_acronym", str: "DBHost", want: "DB_HOST"},
+		{name: "TestScreamingSnakeCase_tailAcronym", str: "UserID", want: "USER_ID"},
+		{name: "TestScreamingSnakeCase_digit", str: "Http2Enabled", want: "HTTP2_ENABLED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := screamingSnakeCase(tt.str); got != tt.want {
+				t.Errorf("screamingSnakeCase() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstanceFromEnviron(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefix  string
+		environ []string
+		opts    []CopyOption
+		want    envConfig
+		wantErr bool
+	}{
+		{
+			name:   "TestInstanceFromEnviron_nested",
+			prefix: "APP",
+			environ: []string{
+				"APP_NAME=svc", "APP_DEBUG=true", "APP_DB_HOST=db.local", "APP_DB_TIMEOUT=1m",
+				"APP_USER_ID=7", "APP_SECRET=x", "APP_INTERNAL=x", "OTHER_NAME=x",
+			},
+			want: envConfig{
+				envCommon: envCommon{Debug: true},
+				Name:      "svc",
+				DB:        envDB{Host: "db.local", Port: 3306, Timeout: time.Minute},
+				UserID:    7,
+			},
+		},
+		{
+			name:    "TestInstanceFromEnviron_nilPtr",
+			environ: []string{"NAME=svc"},
+			want: envConfig{
+				Name: "svc",
+				DB:   envDB{Host: "localhost", Port: 3306, Timeout: 5 * time.Second},
+			},
+		},
+		{
+			name:    "TestInstanceFromEnviron_tag",
+			prefix:  "APP_",
+			environ: []string{"APP_NAME=svc", "APP_REDIS_PORT=6379", "APP_LOG_LEVEL=debug"},
+			want: envConfig{
+				Name:  "svc",
+				DB:    envDB{Host: "localhost", Port: 3306, Timeout: 5 * time.Second},
+				Cache: &envDB{Host: "localhost", Port: 6379, Timeout: 5 * time.Second},
+				Log:   &envLog{Level: "debug"},
+			},
+		},
+		{
+			name:    "TestInstanceFromEnviron_list",
+			environ: []string{"NAME=svc", "HOSTS=a, b,c", "PORTS=80;443"},
+			opts:    []CopyOption{WithEnvSeparator(";")},
+			want: envConfig{
+				Name:  "svc",
+				DB:    envDB{Host: "localhost", Port: 3306, Timeout: 5 * time.Second},
+				Hosts: []string{"a, b,c"},
+				Ports: []int{80, 443},
+			},
+		},
+		{
+			name:    "TestInstanceFromEnviron_durationList",
+			environ: []string{"NAME=svc", "DELAYS=1s,2m", "BACKOFF=100ms, 1s, 1m"},
+			want: envConfig{
+				Name:    "svc",
+				DB:      envDB{Host: "localhost", Port: 3306, Timeout: 5 * time.Second},
+				Delays:  []time.Duration{time.Second, 2 * time.Minute},
+				Backoff: [2]time.Duration{100 * time.Millisecond, time.Second},
+			},
+		},
+		{
+			name:    "TestInstanceFromEnviron_badDurationList",
+			environ: []string{"NAME=svc", "DELAYS=1s,2"},
+			wantErr: true,
+		},
+		{
+			name:    "TestInstanceFromEnviron_required",
+			prefix:  "APP",
+			environ: []string{"NAME=svc"},
+			wantErr: true,
+		},
+		{
+			name:    "TestInstanceFromEnviron_badValue",
+			environ: []string{"NAME=svc", "DB_PORT=abc"},
+			wantErr: true,
+		},
+		{
+			name:    "TestInstanceFromEnviron_badDuration",
+			environ: []string{"NAME=svc", "DB_TIMEOUT=5"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got envConfig
+			err := InstanceFromEnviron(&got, tt.prefix, tt.environ, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("InstanceFromEnviron() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("InstanceFromEnviron() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstanceFromEnvironRecursive(t *testing.T) {
+	var got envNode
+	if err := InstanceFromEnviron(&got, "", []string{"NAME=root", "CHILD_NAME=child"}); err != nil {
+		t.Fatalf("InstanceFromEnviron() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, envNode{Name: "root"}) {
+		t.Errorf("InstanceFromEnviron() = %+v", got)
+	}
+}
+
+func TestInstanceFromEnv(t *testing.T) {
+	t.Setenv("DCOPY_TEST_NAME", "svc")
+	t.Setenv("DCOPY_TEST_HOSTS", "a,b")
+
+	got := envConfig{Log: &envLog{Level: "info"}}
+	if err := InstanceFromEnv(&got, "DCOPY_TEST"); err != nil {
+		t.Fatalf("InstanceFromEnv() error = %v", err)
+	}
+	if got.Name != "svc" || !reflect.DeepEqual(got.Hosts, []string{"a", "b"}) || got.Log.Level != "info" {
+		t.Errorf("InstanceFromEnv() = %+v", got)
+	}
+	if err := InstanceFromEnv(got, "DCOPY_TEST"); err == nil {
+		t.Errorf("InstanceFromEnv() non pointer error = nil")
+	}
+}
@@ -0,0 +1,57 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: equal.go
+ * @time: 2026/10/20 11:20
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"reflect"
+)
+
+// WithTimeEqual Equal时time.Time只按 time.Equal 比较时间点，默认还要求时区一致
+func WithTimeEqual(timeEqual bool) CopyOption {
+	return func(a *args) {
+		a.timeEqual = timeEqual
+	}
+}
+
+// WithNumericEqual Equal时不同类型的数字按数值比较(如 int 和 float64, json.Number)
+// 同时time.Time按 InstanceToMap 的格式与字符串/时间戳比较
+func WithNumericEqual(numericEqual bool) CopyOption {
+	return func(a *args) {
+		a.numericEqual = numericEqual
+	}
+}
+
+// Equal 深度比较a和b，只比较导出字段(WithUnexported 时包括未导出字段)
+// 可选项：
+//   - WithIgnoreFields 忽略的字段，规则同 Diff
+//   - WithNilAsEmpty nil与空的map/slice视为相同
+//   - WithTimeEqual time.Time只比较时间点
+//   - WithNumericEqual 不同类型的数字按数值比较
+//
+// 结构体与 map[string]T 按tag字段名比较，map中缺少的key与0值字段视为相同，用于校验 InstanceFromMap 的结果
+func Equal(a, b interface{}, opts ...CopyOption) bool {
+	return len(EqualReport(a, b, opts...)) == 0
+}
+
+// EqualReport 同 Equal，返回所有不相同的地方，相同时返回nil
+func EqualReport(a, b interface{}, opts ...CopyOption) []Change {
+	optArgs := newOpts(opts...)
+	d := &differ{
+		optArgs:      &optArgs,
+		nilAsEmpty:   optArgs.nilAsEmpty,
+		timeEqual:    optArgs.timeEqual,
+		numericEqual: optArgs.numericEqual,
+	}
+	d.diff(reflect.ValueOf(a), reflect.ValueOf(b), "", "")
+	return d.changes
+}
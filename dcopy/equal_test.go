@@ -0,0 +1,91 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: equal_test.go
+ * @time: 2026/10/20 11:20
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type equalInner struct {
+	Qty int `json:"qty"`
+}
+
+type equalStruct struct {
+	Name    string                 `json:"name"`
+	Count   int                    `json:"count"`
+	Tags    []string               `json:"tags"`
+	Inner   *equalInner            `json:"inner"`
+	Items   []equalInner           `json:"items"`
+	Extra   map[string]interface{} `json:"extra"`
+	Created time.Time              `json:"created"`
+	Updated time.Time              `json:"updated"`
+}
+
+func TestEqual(t *testing.T) {
+	created := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	shanghai := time.FixedZone("CST", 8*3600)
+	base := equalStruct{Name: "a", Count: 1, Inner: &equalInner{Qty: 2}, Created: created}
+	tests := []struct {
+		name string
+		a    interface{}
+		b    interface{}
+		opts []CopyOption
+		want bool
+	}{
+		{name: "TestEqual_same", a: base, b: &base, want: true},
+		{name: "TestEqual_field", a: base, b: equalStruct{Name: "b", Count: 1, Inner: &equalInner{Qty: 2}, Created: created}, want: false},
+		{name: "TestEqual_ignore", a: base, b: equalStruct{Name: "b", Count: 1, Inner: &equalInner{Qty: 2}, Created: created}, opts: []CopyOption{WithIgnoreFields("Name")}, want: true},
+		{name: "TestEqual_nilEmpty", a: base, b: equalStruct{Name: "a", Count: 1, Inner: &equalInner{Qty: 2}, Created: created, Tags: []string{}}, want: false},
+		{name: "TestEqual_nilAsEmpty", a: base, b: equalStruct{Name: "a", Count: 1, Inner: &equalInner{Qty: 2}, Created: created, Tags: []string{}}, opts: []CopyOption{WithNilAsEmpty(true)}, want: true},
+		{name: "TestEqual_timeZone", a: base, b: equalStruct{Name: "a", Count: 1, Inner: &equalInner{Qty: 2}, Created: created.In(shanghai)}, want: false},
+		{name: "TestEqual_timeEqual", a: base, b: equalStruct{Name: "a", Count: 1, Inner: &equalInner{Qty: 2}, Created: created.In(shanghai)}, opts: []CopyOption{WithTimeEqual(true)}, want: true},
+		{name: "TestEqual_number", a: map[string]interface{}{"n": 1}, b: map[string]interface{}{"n": 1.0}, want: false},
+		{name: "TestEqual_numericEqual", a: map[string]interface{}{"n": 1, "m": json.Number("2")}, b: map[string]interface{}{"n": 1.0, "m": int8(2)}, opts: []CopyOption{WithNumericEqual(true)}, want: true},
+		{name: "TestEqual_numericNotEqual", a: []interface{}{uint64(18446744073709551615)}, b: []interface{}{float64(18446744073709551615)}, opts: []CopyOption{WithNumericEqual(true)}, want: false},
+		{name: "TestEqual_structMap", a: base, b: map[string]interface{}{"name": "a", "count": 1, "inner": map[string]interface{}{"qty": 2}, "created": created}, want: true},
+		{name: "TestEqual_structMapExtraKey", a: base, b: map[string]interface{}{"name": "a", "count": 1, "inner": map[string]interface{}{"qty": 2}, "created": created, "x": 1}, want: false},
+		{name: "TestEqual_structMapMissing", a: base, b: map[string]interface{}{"name": "a", "inner": map[string]interface{}{"qty": 2}, "created": created}, want: false},
+		{name: "TestEqual_different", a: 1, b: "1", opts: []CopyOption{WithNumericEqual(true)}, want: false},
+		{name: "TestEqual_nil", a: nil, b: (*equalStruct)(nil), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Equal(tt.a, tt.b, tt.opts...); got != tt.want {
+				t.Errorf("Equal() = %v, want %v, report %+v", got, tt.want, EqualReport(tt.a, tt.b, tt.opts...))
+			}
+		})
+	}
+}
+
+func TestEqualInstanceFromMap(t *testing.T) {
+	from := map[string]interface{}{}
+	data := []byte(`{"name":"a","count":3,"tags":["x"],"inner":{"qty":2},"items":[{"qty":1}],"extra":{"k":1.5},"created":"2026-10-19 08:00:00"}`)
+	if err := json.Unmarshal(data, &from); err != nil {
+		t.Fatal(err)
+	}
+	var dest equalStruct
+	if err := InstanceFromMap(&dest, from); err != nil {
+		t.Fatal(err)
+	}
+	if !Equal(dest, from, WithNumericEqual(true)) {
+		t.Errorf("EqualReport() = %+v", EqualReport(dest, from, WithNumericEqual(true)))
+	}
+	dest.Items[0].Qty = 5
+	want := []Change{{Path: "items[0].qty", Op: ChangeOp_Modified, Old: int64(5), New: 1.0}}
+	if got := EqualReport(dest, from, WithNumericEqual(true)); !reflect.DeepEqual(got, want) {
+		t.Errorf("EqualReport() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,122 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: field_mapping.go
+ * @time: 2026/10/19 18:20
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// WithFieldMapping StructCopy时字段名不一致的映射规则，目标字段路径 -> 来源字段路径
+// 路径以"."分隔，每一级可以是go字段名或tag字段名，开头的结构体类型名可省略
+// 映射先于按字段名的匹配执行，已映射的目标字段不再按字段名匹配
+//
+//	dcopy.WithFieldMapping(map[string]string{
+//		"UserDTO.FullName":     "User.Name",
+//		"UserDTO.Address.City": "User.CityName",   // 展开
+//		"UserDTO.Age":          "User.Profile.Age", // 扁平化
+//	})
+func WithFieldMapping(mapping map[string]string) CopyOption {
+	return func(a *args) {
+		a.fieldMapping = mapping
+	}
+}
+
+// applyFieldMapping 按 WithFieldMapping 拷贝字段，并记录已映射的目标字段路径
+func applyFieldMapping(dest, from reflect.Value, state *structCopyState) error {
+	optArgs := state.optArgs
+	destPaths := make([]string, 0, len(optArgs.fieldMapping))
+	for destPath := range optArgs.fieldMapping {
+		destPaths = append(destPaths, destPath)
+	}
+	sort.Strings(destPaths)
+
+	for _, destPath := range destPaths {
+		fromPath := optArgs.fieldMapping[destPath]
+		fromField, srcPath, err := resolveFieldPath(from, fromPath, optArgs.srcTag, false, optArgs)
+		if err != nil {
+			return fmt.Errorf("field mapping %s: %v", fromPath, err)
+		}
+		state.mappedSrc[srcPath] = struct{}{}
+		// 先检查目标路径，来源为nil时不分配目标的指针
+		_, fieldPath, err := resolveFieldPath(dest, destPath, optArgs.destTag, false, optArgs)
+		if err != nil {
+			return fmt.Errorf("field mapping %s: %v", destPath, err)
+		}
+		state.mapped[fieldPath] = struct{}{}
+		if !fromField.IsValid() {
+			// 来源路径途经nil指针，同来源字段为nil指针，不拷贝
+			state.miss += 1
+			continue
+		}
+		if optArgs.omitempty && fromField.IsZero() {
+			continue
+		}
+		destField, _, _ := resolveFieldPath(dest, destPath, optArgs.destTag, true, optArgs)
+		destField, ok := exposeValue(destField)
+		if !ok || !destField.CanSet() {
+			state.miss += 1
+			state.report.UnexportedSkipped = append(state.report.UnexportedSkipped, fieldPath)
+			continue
+		}
+		copyField(destField, fromField, fieldPath, state)
+	}
+	return nil
+}
+
+// resolveFieldPath 按字段路径查找字段，返回字段值和go字段名组成的路径
+// alloc为true时途经的nil指针会自动分配，否则返回无效值(路径仍会完整检查)
+func resolveFieldPath(inst reflect.Value, path, tag string, alloc bool, optArgs *args) (reflect.Value, string, error) {
+	segs := strings.Split(path, ".")
+	if len(segs) > 1 && segs[0] == inst.Type().Name() {
+		segs = segs[1:]
+	}
+	names := make([]string, 0, len(segs))
+	missing := false
+	for i, seg := range segs {
+		if i > 0 && inst.Kind() == reflect.Ptr {
+			if inst.IsNil() {
+				if alloc {
+					inst.Set(reflect.New(inst.Type().Elem()))
+				} else {
+					// 用零值继续检查后续路径
+					missing = true
+					inst = reflect.New(inst.Type()).Elem()
+					inst.Set(reflect.New(inst.Type().Elem()))
+				}
+			}
+			inst = inst.Elem()
+		}
+		if inst.Kind() != reflect.Struct {
+			return reflect.Value{}, "", fmt.Errorf("%s is not struct", strings.Join(segs[:i], "."))
+		}
+		index, ok := newFieldIndex(inst.Type(), tag, optArgs).find(seg, optArgs)
+		if !ok {
+			return reflect.Value{}, "", fmt.Errorf("field %s not found", seg)
+		}
+		fieldType := inst.Type().FieldByIndex(index)
+		names = append(names, fieldType.Name)
+		if alloc {
+			inst = streamFieldByIndex(inst, index)
+		} else if inst = fieldByIndex(inst, index); !inst.IsValid() {
+			missing = true
+			inst = reflect.New(fieldType.Type).Elem()
+		}
+	}
+	if missing {
+		return reflect.Value{}, strings.Join(names, "."), nil
+	}
+	return inst, strings.Join(names, "."), nil
+}
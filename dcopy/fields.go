@@ -0,0 +1,176 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: fields.go
+ * @time: 2026/10/19 20:50
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// structField 展开匿名组合后的字段
+type structField struct {
+	name      string // 按 getFieldTag 获取的字段名
+	index     []int
+	omitempty bool
+	tagged    bool // 字段名来自tag
+	inlineMap bool // 匿名组合的map类型，与当前层的数据合并
+	fieldType reflect.StructField
+}
+
+// structFields 按 encoding/json 的规则展开结构体字段
+//   - 匿名组合的结构体(或指针)默认展开到当前层，tag中指定了字段名时作为普通字段嵌套，如 json:"inner"
+//   - dcopy:",squash" 或 json:",inline" 强制展开普通字段
+//   - 字段名冲突时层级浅的优先；同一层级时有tag字段名的优先，仍冲突则都忽略
+func structFields(tpe reflect.Type, optArgs *args) []structField {
+	type queued struct {
+		tpe   reflect.Type
+		index []int
+	}
+	out := make([]structField, 0, tpe.NumField())
+	defined := map[string]bool{}
+	visited := map[reflect.Type]bool{}
+	next := []queued{{tpe: tpe}}
+	for len(next) > 0 {
+		current := next
+		next = nil
+		level := map[string][]structField{}
+		names := make([]string, 0, 8)
+		for _, q := range current {
+			if visited[q.tpe] {
+				continue
+			}
+			visited[q.tpe] = true
+			for i := 0; i < q.tpe.NumField(); i++ {
+				fieldType := q.tpe.Field(i)
+				if isUnexportedField(fieldType) && optArgs.unexportedMode != UnexportedMode_Copy {
+					continue
+				}
+				name, omitempty, ignore := getFieldTag(fieldType, optArgs)
+				if ignore {
+					continue
+				}
+				index := append(append(make([]int, 0, len(q.index)+1), q.index...), i)
+				if isSquashField(fieldType, optArgs) {
+					sub := fieldType.Type
+					if sub.Kind() == reflect.Ptr {
+						sub = sub.Elem()
+					}
+					next = append(next, queued{tpe: sub, index: index})
+					continue
+				}
+				if defined[name] { // 外层已定义
+					continue
+				}
+				if _, ok := level[name]; !ok {
+					names = append(names, name)
+				}
+				level[name] = append(level[name], structField{
+					name:      name,
+					index:     index,
+					omitempty: omitempty,
+					tagged:    hasTagName(fieldType, optArgs),
+					inlineMap: fieldType.Type.Kind() == reflect.Map && squashTag(fieldType, optArgs),
+					fieldType: fieldType,
+				})
+			}
+		}
+		for _, name := range names {
+			defined[name] = true
+			if field, ok := dominantField(level[name]); ok {
+				out = append(out, field)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i].index, out[j].index
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	return out
+}
+
+// dominantField 同一层级同名字段中有且只有一个带tag字段名的胜出
+func dominantField(fields []structField) (structField, bool) {
+	if len(fields) == 1 {
+		return fields[0], true
+	}
+	tagged := make([]structField, 0, 1)
+	for _, field := range fields {
+		if field.tagged {
+			tagged = append(tagged, field)
+		}
+	}
+	if len(tagged) == 1 {
+		return tagged[0], true
+	}
+	return structField{}, false
+}
+
+// isSquashField 字段是否展开到当前层
+func isSquashField(fieldType reflect.StructField, optArgs *args) bool {
+	tpe := fieldType.Type
+	if tpe.Kind() == reflect.Ptr {
+		tpe = tpe.Elem()
+	}
+	if tpe.Kind() != reflect.Struct || !isNestedStruct(tpe) {
+		return false
+	}
+	return squashTag(fieldType, optArgs)
+}
+
+// squashTag 只按tag和是否匿名判断，不检查字段类型
+func squashTag(fieldType reflect.StructField, optArgs *args) bool {
+	if hasTagOption(fieldType.Tag.Get("dcopy"), "squash") || hasTagOption(fieldType.Tag.Get("json"), "inline") {
+		return true
+	}
+	return fieldType.Anonymous && !hasTagName(fieldType, optArgs)
+}
+
+// hasTagName getFieldTag 使用的tag中是否指定了字段名
+func hasTagName(fieldType reflect.StructField, optArgs *args) bool {
+	tags := []string{"json", "gorm", "xorm"}
+	switch optArgs.curGetFieldType {
+	case FieldType_Origin:
+		return false
+	case FieldType_Json:
+		tags = tags[:1]
+	case FieldType_Gorm:
+		tags = tags[1:2]
+	case FieldType_Xorm:
+		tags = tags[2:]
+	case FieldType_Form:
+		tags = []string{"form", "json"}
+	}
+	for _, tag := range tags {
+		if name, _, ignore := parseTagName(fieldType, tag); name != "" && !ignore {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTagOption tag的选项部分是否包含option，如 json:",inline"
+func hasTagOption(tagStr, option string) bool {
+	arr := strings.Split(tagStr, ",")
+	for _, it := range arr[1:] {
+		if strings.TrimSpace(it) == option {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: fields_test.go
+ * @time: 2026/10/19 21:10
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"reflect"
+	"testing"
+)
+
+type SquashA struct {
+	Name string `json:"name"`
+	Dup  string `json:"dup"`
+}
+
+type SquashB struct {
+	Dup    string `json:"dup"`
+	Tagged string `json:"tagged"`
+}
+
+type SquashC struct {
+	Tagged string
+}
+
+type SquashOuter struct {
+	*SquashA
+	SquashB
+	SquashC
+	Name  string    `json:"name"`
+	Inner SquashA   `dcopy:",squash"`
+	Nest  SquashB   `json:"nest"`
+	Named *SquashC  `json:"named"`
+	Extra squashNil `json:"extra"`
+}
+
+type squashNil struct {
+	Value int `json:"value"`
+}
+
+func TestStructFields(t *testing.T) {
+	fields := structFields(reflect.TypeOf(SquashOuter{}), &args{})
+	got := map[string][]int{}
+	for _, sf := range fields {
+		got[sf.name] = sf.index
+	}
+	want := map[string][]int{
+		"name":   {3},    // 外层覆盖 SquashA.Name 和 Inner.Name
+		"tagged": {1, 1}, // 同层有tag的 SquashB.Tagged 胜出
+		"nest":   {5},
+		"named":  {6},
+		"extra":  {7},
+	}
+	// dup 在 SquashA/SquashB/Inner 中冲突，全部忽略
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("structFields() = %v, want %v", got, want)
+	}
+}
+
+func TestEmbeddedSquash(t *testing.T) {
+	type Base struct {
+		ID int64 `json:"id"`
+	}
+	type Meta struct {
+		Version int `json:"version"`
+	}
+	type Doc struct {
+		*Base
+		Meta  `json:"meta"`
+		Attrs Meta   `dcopy:",squash"`
+		Title string `json:"title"`
+	}
+
+	doc := Doc{Base: &Base{ID: 1}, Meta: Meta{Version: 2}, Attrs: Meta{Version: 3}, Title: "t"}
+	out, err := InstanceToMap(doc)
+	if err != nil {
+		t.Fatalf("InstanceToMap() error = %v", err)
+	}
+	want := map[string]interface{}{
+		"id":      int64(1),
+		"meta":    map[string]interface{}{"version": int64(2)},
+		"version": int64(3),
+		"title":   "t",
+	}
+	if !reflect.DeepEqual(out, want) {
+		t.Errorf("InstanceToMap() = %v, want %v", out, want)
+	}
+
+	// nil的匿名组合指针不输出
+	out, err = InstanceToMap(Doc{Title: "t"})
+	if _, ok := out["id"]; err != nil || ok {
+		t.Errorf("InstanceToMap() = %v, %v", out, err)
+	}
+
+	got := Doc{}
+	if err := InstanceFromMap(&got, want); err != nil {
+		t.Fatalf("InstanceFromMap() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, doc) {
+		t.Errorf("InstanceFromMap() = %+v, want %+v", got, doc)
+	}
+
+	// 没有对应数据时不分配匿名组合指针
+	got = Doc{}
+	if err := InstanceFromMap(&got, map[string]interface{}{"title": "t"}); err != nil || got.Base != nil {
+		t.Errorf("InstanceFromMap() = %+v, %v", got, err)
+	}
+
+	got = Doc{}
+	if err := InstanceFromBytes(&got, []byte(`{"id":1,"meta":{"version":2},"version":3,"title":"t"}`)); err != nil {
+		t.Fatalf("InstanceFromBytes() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, doc) {
+		t.Errorf("InstanceFromBytes() = %+v, want %+v", got, doc)
+	}
+}
@@ -0,0 +1,174 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: form.go
+ * @time: 2026/10/20 16:40
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// formMaxSliceLen 表单key中slice下标的上限，防止 items[99999999] 分配过大的slice
+const formMaxSliceLen = 1000
+
+// InstanceFromValues 解析表单数据到dest，dest必须为指针
+// key支持点号和方括号表示嵌套，如 user[name], user.name, items[0].id；tags=a&tags=b 或 tags[]=a 写入slice
+// 字段名默认按 FieldType_Form 匹配(form -> json -> 小驼峰)，不存在的字段忽略；值按 InstanceFromMap 的规则转换
+func InstanceFromValues(dest interface{}, values url.Values, opts ...CopyOption) (err error) {
+	inst := reflect.ValueOf(dest)
+	if inst.Kind() != reflect.Ptr || inst.IsNil() {
+		return errors.New("not pointer target")
+	}
+	optArgs := newOpts(append([]CopyOption{WithFieldType(FieldType_Form)}, opts...)...)
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("instance from values err=[%v]", r)
+			printLog(&optArgs, 0, r)
+		}
+	}()
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	w := pathWalker{optArgs: &optArgs, tagOnly: true, grow: formMaxSliceLen}
+	for _, key := range keys {
+		vals := values[key]
+		if len(vals) == 0 {
+			continue
+		}
+		segs, err := parsePath(strings.TrimSuffix(key, "[]"))
+		if err != nil {
+			return err
+		}
+		err = w.modify(inst.Elem(), segs, func(field reflect.Value) error {
+			return setFormValue(field, vals, key, &optArgs)
+		})
+		if errors.Is(err, errPathNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("form key %q: %v", key, err)
+		}
+	}
+	return nil
+}
+
+// InstanceToValues 把src转换为表单数据，src为结构体或map(及其指针)
+// 嵌套字段用点号连接，含特殊字符的key用方括号；基础类型的slice写成重复的key，结构体的slice写成 items[0].id
+// nil值忽略，字段名默认按 FieldType_Form 获取
+func InstanceToValues(src interface{}, opts ...CopyOption) (out url.Values, err error) {
+	optArgs := newOpts(append([]CopyOption{WithFieldType(FieldType_Form)}, opts...)...)
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New(interface2String(r))
+			printLog(&optArgs, 0, r)
+		}
+	}()
+
+	generic, err := toGenericValue(reflect.ValueOf(src), &optArgs)
+	if err != nil {
+		return nil, err
+	}
+	mp, ok := generic.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("src must be struct or map")
+	}
+	out = url.Values{}
+	flattenValues(out, "", mp)
+	return out, nil
+}
+
+// setFormValue 把表单中同一个key的所有值写入field
+// slice/array取全部值，interface{}多个值时取全部，其余类型取第一个值
+func setFormValue(field reflect.Value, vals []string, key string, optArgs *args) error {
+	tpe := field.Type()
+	for tpe.Kind() == reflect.Ptr {
+		tpe = tpe.Elem()
+	}
+	switch {
+	case tpe.Kind() == reflect.Slice || tpe.Kind() == reflect.Array:
+		items := make([]interface{}, len(vals))
+		for i, val := range vals {
+			items[i] = formValue(tpe.Elem(), val)
+			if err := checkConvertible(tpe.Elem(), items[i], optArgs); err != nil {
+				return err
+			}
+		}
+		return assignValue(field, items, key, optArgs)
+	case tpe.Kind() == reflect.Interface && len(vals) > 1:
+		items := make([]interface{}, len(vals))
+		for i, val := range vals {
+			items[i] = val
+		}
+		return assignValue(field, items, key, optArgs)
+	}
+	val := formValue(tpe, vals[0])
+	if err := checkConvertible(tpe, val, optArgs); err != nil {
+		return err
+	}
+	return assignValue(field, val, key, optArgs)
+}
+
+// formValue 复选框提交的 on 按true处理
+func formValue(tpe reflect.Type, val string) interface{} {
+	for tpe.Kind() == reflect.Ptr {
+		tpe = tpe.Elem()
+	}
+	if tpe.Kind() == reflect.Bool && val == "on" {
+		return "true"
+	}
+	return val
+}
+
+// flattenValues 把 InstanceToMap 格式的通用数据展开写入values
+func flattenValues(values url.Values, prefix string, v interface{}) {
+	switch d := v.(type) {
+	case nil:
+	case map[string]interface{}:
+		for _, key := range sortedKeys(d) {
+			flattenValues(values, formKey(prefix, key), d[key])
+		}
+	case []interface{}:
+		for i, it := range d {
+			switch it.(type) {
+			case map[string]interface{}, []interface{}:
+				flattenValues(values, prefix+"["+strconv.Itoa(i)+"]", it)
+			default:
+				flattenValues(values, prefix, it)
+			}
+		}
+	default:
+		values.Add(prefix, interface2String(d))
+	}
+}
+
+// formKey 拼接表单key，key中含 . [ ] 时用方括号，含 ] 或以引号开头时加引号
+func formKey(prefix, key string) string {
+	switch {
+	case key != "" && !strings.ContainsAny(key, ".[]"):
+		if prefix == "" {
+			return key
+		}
+		return prefix + "." + key
+	case key != "" && !strings.Contains(key, "]") && key[0] != '"' && key[0] != '\'':
+		return prefix + "[" + key + "]"
+	}
+	return prefix + "[" + strconv.Quote(key) + "]"
+}
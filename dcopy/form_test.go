@@ -0,0 +1,227 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: form_test.go
+ * @time: 2026/10/20 16:40
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type formUser struct {
+	Name  string `form:"name"`
+	Email string `json:"mail"`
+	Age   int    `form:"age"`
+}
+
+type formItem struct {
+	ID  int64  `form:"id"`
+	Sku string `form:"sku"`
+}
+
+type formRequest struct {
+	User     formUser          `form:"user"`
+	Items    []formItem        `form:"items"`
+	Tags     []string          `form:"tags"`
+	Scores   []int             `form:"scores"`
+	Agree    bool              `form:"agree"`
+	Page     *int              `form:"page"`
+	Attrs    map[string]string `form:"attrs"`
+	Created  time.Time         `form:"created"`
+	Password string            `form:"-"`
+	Remark   string
+}
+
+func TestInstanceFromValues(t *testing.T) {
+	page := 2
+	tests := []struct {
+		name    string
+		values  url.Values
+		opts    []CopyOption
+		want    formRequest
+		wantErr bool
+	}{
+		{
+			name:   "TestInstanceFromValues_bracket",
+			values: url.Values{"user[name]": {"tom"}, "user[mail]": {"t@x.com"}, "user[age]": {"18"}},
+			want:   formRequest{User: formUser{Name: "tom", Email: "t@x.com", Age: 18}},
+		},
+		{
+			name:   "TestInstanceFromValues_dot",
+			values: url.Values{"user.name": {"tom"}, "page": {"2"}, "remark": {"hi"}},
+			want:   formRequest{User: formUser{Name: "tom"}, Page: &page, Remark: "hi"},
+		},
+		{
+			name:   "TestInstanceFromValues_sliceOfStruct",
+			values: url.Values{"items[1].id": {"2"}, "items[0].id": {"1"}, "items[0][sku]": {"a"}},
+			want:   formRequest{Items: []formItem{{ID: 1, Sku: "a"}, {ID: 2}}},
+		},
+		{
+			name:   "TestInstanceFromValues_repeated",
+			values: url.Values{"tags": {"a", "b"}, "scores[]": {"1", "2"}},
+			want:   formRequest{Tags: []string{"a", "b"}, Scores: []int{1, 2}},
+		},
+		{
+			name:   "TestInstanceFromValues_checkbox",
+			values: url.Values{"agree": {"on"}},
+			want:   formRequest{Agree: true},
+		},
+		{
+			name:   "TestInstanceFromValues_map",
+			values: url.Values{"attrs[a.b]": {"1"}, "attrs.c": {"2"}},
+			want:   formRequest{Attrs: map[string]string{"a.b": "1", "c": "2"}},
+		},
+		{
+			name:   "TestInstanceFromValues_time",
+			values: url.Values{"created": {"2026-10-20"}},
+			opts:   []CopyOption{WithTimeFormatStr("2006-01-02")},
+			want:   formRequest{Created: time.Date(2026, 10, 20, 0, 0, 0, 0, time.Local)},
+		},
+		{
+			name:   "TestInstanceFromValues_unknown",
+			values: url.Values{"nothing": {"x"}, "user[nothing]": {"x"}, "password": {"x"}, "name": {"tom"}},
+			want:   formRequest{},
+		},
+		{
+			name:    "TestInstanceFromValues_badNumber",
+			values:  url.Values{"user[age]": {"abc"}},
+			wantErr: true,
+		},
+		{
+			name:    "TestInstanceFromValues_indexTooLarge",
+			values:  url.Values{"items[100000].id": {"1"}},
+			wantErr: true,
+		},
+		{
+			name:    "TestInstanceFromValues_badKey",
+			values:  url.Values{"user[name": {"tom"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got formRequest
+			err := InstanceFromValues(&got, tt.values, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("InstanceFromValues() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("InstanceFromValues() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstanceFromValues_map(t *testing.T) {
+	got := map[string]interface{}{}
+	values := url.Values{"user[name]": {"tom"}, "tags": {"a", "b"}}
+	if err := InstanceFromValues(&got, values); err != nil {
+		t.Fatalf("InstanceFromValues() error = %v", err)
+	}
+	want := map[string]interface{}{
+		"user": map[string]interface{}{"name": "tom"},
+		"tags": []interface{}{"a", "b"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("InstanceFromValues() = %v, want %v", got, want)
+	}
+	if err := InstanceFromValues(got, values); err == nil {
+		t.Errorf("InstanceFromValues() non pointer error = nil")
+	}
+}
+
+func TestInstanceToValues(t *testing.T) {
+	page := 2
+	tests := []struct {
+		name    string
+		src     interface{}
+		opts    []CopyOption
+		want    url.Values
+		wantErr bool
+	}{
+		{
+			name: "TestInstanceToValues_struct",
+			src: &formRequest{
+				User:     formUser{Name: "tom", Age: 18},
+				Items:    []formItem{{ID: 1, Sku: "a"}},
+				Tags:     []string{"a", "b"},
+				Page:     &page,
+				Attrs:    map[string]string{"a.b": "1"},
+				Created:  time.Date(2026, 10, 20, 0, 0, 0, 0, time.Local),
+				Password: "secret",
+			},
+			opts: []CopyOption{WithTimeFormatStr("2006-01-02")},
+			want: url.Values{
+				"user.name":    {"tom"},
+				"user.mail":    {""},
+				"user.age":     {"18"},
+				"items[0].id":  {"1"},
+				"items[0].sku": {"a"},
+				"tags":         {"a", "b"},
+				"agree":        {"false"},
+				"page":         {"2"},
+				"attrs[a.b]":   {"1"},
+				"created":      {"2026-10-20"},
+				"remark":       {""},
+			},
+		},
+		{
+			name: "TestInstanceToValues_map",
+			src:  map[string]interface{}{"q": "go", "n": 1, "x]": "y", "nil": nil},
+			want: url.Values{"q": {"go"}, "n": {"1"}, `["x]"]`: {"y"}},
+		},
+		{
+			name:    "TestInstanceToValues_notObject",
+			src:     []int{1, 2},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InstanceToValues(tt.src, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("InstanceToValues() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("InstanceToValues() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstanceValues_roundTrip(t *testing.T) {
+	page := 3
+	src := formRequest{
+		User:    formUser{Name: "tom", Email: "t@x.com", Age: 18},
+		Items:   []formItem{{ID: 1, Sku: "a"}, {ID: 2, Sku: "b"}},
+		Tags:    []string{"x", "y"},
+		Scores:  []int{7, 8},
+		Agree:   true,
+		Page:    &page,
+		Attrs:   map[string]string{"a.b": "1", "c]": "2"},
+		Created: time.Date(2026, 10, 20, 8, 30, 0, 0, time.Local),
+		Remark:  "hi",
+	}
+	values, err := InstanceToValues(&src)
+	if err != nil {
+		t.Fatalf("InstanceToValues() error = %v", err)
+	}
+	var got formRequest
+	if err := InstanceFromValues(&got, values); err != nil {
+		t.Fatalf("InstanceFromValues() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, src) {
+		t.Errorf("round trip = %+v, want %+v", got, src)
+	}
+}
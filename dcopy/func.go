@@ -14,6 +14,7 @@ package dcopy
 
 import (
 	"strings"
+	"unicode"
 )
 
 
@@ -24,4 +25,21 @@ func littleCamelCase(str string) string {
 	first := string(str[0])
 	tail := str[1:]
 	return strings.ToLower(first) + tail
-}
\ No newline at end of file
+}
+
+// screamingSnakeCase 驼峰转为大写下划线，如 DBHost -> DB_HOST, UserID -> USER_ID
+func screamingSnakeCase(str string) string {
+	runes := []rune(str)
+	buf := make([]rune, 0, len(runes)+4)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				(unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				buf = append(buf, '_')
+			}
+		}
+		buf = append(buf, unicode.ToUpper(r))
+	}
+	return string(buf)
+}
@@ -0,0 +1,162 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: gen.go
+ * @time: 2026/10/19 10:12
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"errors"
+	"reflect"
+	"time"
+)
+
+// 本文件中的导出函数供 cmd/dcopy-gen 生成的代码调用，
+// 保证生成代码和反射实现使用同一套标签/命名/类型转换规则
+
+// FieldTagName 按照 getFieldTag 的规则获取字段名
+// return fieldname, omitempty, ignore
+func FieldTagName(field reflect.StructField, opts ...CopyOption) (string, bool, bool) {
+	optArgs := newOpts(opts...)
+	return getFieldTag(field, &optArgs)
+}
+
+// FieldSquash 结构体类型的字段是否展开到当前层，规则同 InstanceToMap
+// 匿名组合默认展开，tag中指定了字段名时嵌套；dcopy:",squash" 或 json:",inline" 强制展开
+func FieldSquash(field reflect.StructField, opts ...CopyOption) bool {
+	optArgs := newOpts(opts...)
+	return squashTag(field, &optArgs)
+}
+
+// ToInt64 同 InstanceFromMap 中整型字段的转换规则
+func ToInt64(v interface{}) int64 {
+	return interface2Int64(v)
+}
+
+// ToUint64 同 InstanceFromMap 中无符号整型字段的转换规则
+func ToUint64(v interface{}) uint64 {
+	return interface2Uint64(v)
+}
+
+// ToFloat64 同 InstanceFromMap 中浮点字段的转换规则
+func ToFloat64(v interface{}) float64 {
+	return interface2Float64(v)
+}
+
+// ToString 同 InstanceFromMap 中字符串字段的转换规则
+func ToString(v interface{}) string {
+	return interface2String(v)
+}
+
+// ToBool 同 InstanceFromMap 中布尔字段的转换规则
+func ToBool(v interface{}) bool {
+	return interface2Bool(v)
+}
+
+// ToTime 按 WithTimeValType/WithTimeFormatStr 解析时间
+// 解析失败返回false，此时InstanceFromMap不会修改目标字段
+func ToTime(v interface{}, opts ...CopyOption) (time.Time, bool) {
+	optArgs := newOpts(opts...)
+	if optArgs.timeValType == TimeValType_String {
+		t, err := time.ParseInLocation(optArgs.timeFmtStr, interface2String(v), time.Local)
+		return t, err == nil
+	} else if optArgs.timeValType == TimeValType_Int64 {
+		return time.Unix(interface2Int64(v), 0), true
+	}
+	return time.Time{}, false
+}
+
+// FromTime 按 WithTimeValType/WithTimeFormatStr 输出时间，同 InstanceToMap
+func FromTime(t time.Time, opts ...CopyOption) interface{} {
+	optArgs := newOpts(opts...)
+	if optArgs.timeValType == TimeValType_Int64 {
+		return t.Unix()
+	}
+	return t.Format(optArgs.timeFmtStr)
+}
+
+// ValueToMapValue 将任意字段值转换成 InstanceToMap 中对应的输出值
+// struct -> map[string]interface{}, map -> map[string]interface{}, slice -> []interface{}
+func ValueToMapValue(v interface{}, opts ...CopyOption) (out interface{}, err error) {
+	if v == nil {
+		return nil, nil
+	}
+	optArgs := newOpts(opts...)
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New(interface2String(r))
+			printLog(&optArgs, 0, r)
+		}
+	}()
+
+	field := reflect.ValueOf(v)
+	if field.Kind() == reflect.Ptr && !field.IsNil() {
+		field = field.Elem()
+	}
+	if val, isNil := nilToMapValue(field, &optArgs); isNil {
+		return val, nil
+	}
+	switch field.Kind() {
+	case reflect.Struct:
+		if t, ok := field.Interface().(time.Time); ok {
+			return FromTime(t, opts...), nil
+		}
+		if val, ok := bigToValue(field); ok {
+			return val, nil
+		}
+		subMap := make(map[string]interface{}, field.NumField())
+		err = instanceToMap(subMap, field, 0, &optArgs)
+		return subMap, err
+	case reflect.Map:
+		subMap := make(map[string]interface{}, field.Len())
+		err = instanceMapToMap(subMap, field, 0, &optArgs)
+		return subMap, err
+	case reflect.Slice:
+		subSlice := make([]interface{}, field.Len())
+		err = instanceSliceToArr(subSlice, field, 0, &optArgs)
+		return subSlice, err
+	default:
+		return getBasicValue(field.Interface()), nil
+	}
+}
+
+// ElemToMapValue 将slice/map中的元素转换成 InstanceToMap 中对应的输出值
+// 规则同 instanceSliceToArr，与字段不同，基础类型的元素保持原类型
+func ElemToMapValue(v interface{}, opts ...CopyOption) (out interface{}, err error) {
+	if v == nil {
+		return nil, nil
+	}
+	optArgs := newOpts(opts...)
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New(interface2String(r))
+			printLog(&optArgs, 0, r)
+		}
+	}()
+
+	sl := reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(v)), 1, 1)
+	sl.Index(0).Set(reflect.ValueOf(v))
+	arr := make([]interface{}, 1)
+	err = instanceSliceToArr(arr, sl, 0, &optArgs)
+	return arr[0], err
+}
+
+// BasicValue 同 InstanceToMap 中interface{}字段的输出，自定义类型转换成基础类型
+func BasicValue(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	return getBasicValue(v)
+}
+
+// IsEmptyValue 同 InstanceToMap 中interface{}字段的omitempty判断
+func IsEmptyValue(v interface{}) bool {
+	return v == nil || valueEmpty(v)
+}
@@ -0,0 +1,73 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: gen_test.go
+ * @time: 2026/10/19 14:32
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestValueToMapValue(t *testing.T) {
+	type args struct {
+		v    interface{}
+		opts []CopyOption
+	}
+	tests := []struct {
+		name string
+		args args
+		want interface{}
+	}{
+		{
+			name: "TestValueToMapValue_struct",
+			args: args{v: &InnerStruct{A: 1, B: "b"}},
+			want: map[string]interface{}{"aa": int64(1), "bb": "b"},
+		},
+		{
+			name: "TestValueToMapValue_slice",
+			args: args{v: []InnerStruct{{A: 1}}},
+			want: []interface{}{map[string]interface{}{"aa": int64(1), "bb": ""}},
+		},
+		{
+			name: "TestValueToMapValue_time",
+			args: args{v: time.Unix(100, 0), opts: []CopyOption{WithTimeValType(TimeValType_Int64)}},
+			want: int64(100),
+		},
+		{
+			name: "TestValueToMapValue_nil",
+			args: args{v: (*InnerStruct)(nil)},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValueToMapValue(tt.args.v, tt.args.opts...)
+			if err != nil {
+				t.Fatalf("ValueToMapValue() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValueToMapValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToTime(t *testing.T) {
+	got, ok := ToTime("2020-10-16 00:00:00")
+	if !ok || got.Year() != 2020 {
+		t.Errorf("ToTime() = %v, %v", got, ok)
+	}
+	if _, ok := ToTime("bad"); ok {
+		t.Errorf("ToTime() want parse failure")
+	}
+}
@@ -0,0 +1,97 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: generic.go
+ * @time: 2026/10/19 15:20
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// newTarget 返回写入out时使用的指针
+// T本身是指针类型时，自动分配指向的对象
+func newTarget[T any](out *T) interface{} {
+	inst := reflect.ValueOf(out).Elem()
+	if inst.Kind() == reflect.Ptr {
+		inst.Set(reflect.New(inst.Type().Elem()))
+		return inst.Interface()
+	}
+	return out
+}
+
+// FromMap 泛型版 InstanceFromMap
+//
+//	user, err := dcopy.FromMap[User](kvs)
+func FromMap[T any](m map[string]interface{}, opts ...CopyOption) (T, error) {
+	var out T
+	err := InstanceFromMap(newTarget(&out), m, opts...)
+	return out, err
+}
+
+// FromBytes 泛型版 InstanceFromBytes
+func FromBytes[T any](data []byte, opts ...CopyOption) (T, error) {
+	var out T
+	err := InstanceFromBytes(newTarget(&out), data, opts...)
+	return out, err
+}
+
+// ToMap 泛型版 InstanceToMap
+func ToMap[T any](from T, opts ...CopyOption) (map[string]interface{}, error) {
+	return InstanceToMap(from, opts...)
+}
+
+// Copy 泛型版 InstanceCopy，返回新的D
+//
+//	entity, err := dcopy.Copy[UserEntity](dto)
+func Copy[D, S any](src S, opts ...CopyOption) (D, error) {
+	var out D
+	err := InstanceCopy(newTarget(&out), src, opts...)
+	return out, err
+}
+
+// Clone 深度拷贝，指针/map/slice均重新分配
+// 未导出字段默认为0值，WithUnexported(UnexportedMode_Copy) 时同样深度拷贝；time.Time/big类型整体拷贝
+func Clone[T any](from T, opts ...CopyOption) T {
+	optArgs := newOpts(opts...)
+	var out T
+	cloneValue(reflect.ValueOf(&out).Elem(), reflect.ValueOf(&from).Elem(), newCloneState(), &optArgs)
+	return out
+}
+
+// GetField 泛型版 GetFieldValue，支持同样的路径
+// 字段类型与V不一致时，按 InstanceFromMap 的规则转换
+func GetField[V any](target interface{}, fieldOrTagName string, opts ...CopyOption) (out V, err error) {
+	if target == nil {
+		return out, errors.New("nil target")
+	}
+	optArgs := newOpts(opts...)
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New(interface2String(r))
+			printLog(&optArgs, 0, r)
+		}
+	}()
+
+	field, err := lookupPath(reflect.ValueOf(target), fieldOrTagName, &optArgs)
+	if err != nil {
+		return out, err
+	}
+	if !field.CanInterface() {
+		return out, fmt.Errorf("field %s cannot be accessed", fieldOrTagName)
+	}
+	if v, ok := field.Interface().(V); ok {
+		return v, nil
+	}
+	err = valueDeepCopy(reflect.ValueOf(&out).Elem(), field.Interface(), 0, fieldOrTagName, &optArgs)
+	return out, err
+}
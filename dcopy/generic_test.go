@@ -0,0 +1,131 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: generic_test.go
+ * @time: 2026/10/19 15:48
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFromMap(t *testing.T) {
+	got, err := FromMap[InnerStruct](map[string]interface{}{"aa": "12", "bb": 34})
+	if err != nil {
+		t.Fatalf("FromMap() error = %v", err)
+	}
+	if want := (InnerStruct{A: 12, B: "34"}); got != want {
+		t.Errorf("FromMap() = %v, want %v", got, want)
+	}
+
+	ptr, err := FromMap[*InnerStruct](map[string]interface{}{"aa": 1})
+	if err != nil || ptr == nil || ptr.A != 1 {
+		t.Errorf("FromMap() = %v, %v", ptr, err)
+	}
+}
+
+func TestFromBytes(t *testing.T) {
+	got, err := FromBytes[InnerStruct]([]byte(`{"aa":"123","bb":"x"}`))
+	if err != nil {
+		t.Fatalf("FromBytes() error = %v", err)
+	}
+	if want := (InnerStruct{A: 123, B: "x"}); got != want {
+		t.Errorf("FromBytes() = %v, want %v", got, want)
+	}
+}
+
+func TestToMap(t *testing.T) {
+	got, err := ToMap(InnerStruct{A: 1, B: "b"})
+	if err != nil {
+		t.Fatalf("ToMap() error = %v", err)
+	}
+	if want := map[string]interface{}{"aa": int64(1), "bb": "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ToMap() = %v, want %v", got, want)
+	}
+}
+
+func TestCopy(t *testing.T) {
+	type Src struct {
+		A int
+		B string
+	}
+	type Dst struct {
+		A int64
+		B string
+	}
+	got, err := Copy[Dst](Src{A: 1, B: "b"})
+	if err != nil {
+		t.Fatalf("Copy() error = %v", err)
+	}
+	if want := (Dst{A: 1, B: "b"}); got != want {
+		t.Errorf("Copy() = %v, want %v", got, want)
+	}
+
+	ptr, err := Copy[*Dst](&Src{A: 2})
+	if err != nil || ptr == nil || ptr.A != 2 {
+		t.Errorf("Copy() = %v, %v", ptr, err)
+	}
+
+	fromMap, err := Copy[InnerStruct](map[string]interface{}{"aa": 3})
+	if err != nil || fromMap.A != 3 {
+		t.Errorf("Copy() = %v, %v", fromMap, err)
+	}
+}
+
+func TestClone(t *testing.T) {
+	type Node struct {
+		Name  string
+		Time  time.Time
+		Tags  []string
+		Attrs map[string]*InnerStruct
+		Next  *Node
+		Any   interface{}
+	}
+	now := time.Now()
+	src := &Node{
+		Name:  "a",
+		Time:  now,
+		Tags:  []string{"x"},
+		Attrs: map[string]*InnerStruct{"k": {A: 1}},
+		Any:   []int{1},
+	}
+	src.Next = src
+
+	got := Clone(src)
+	if got == src || got.Next != got {
+		t.Fatalf("Clone() did not keep cycle on the new value")
+	}
+	if !got.Time.Equal(now) || got.Name != "a" {
+		t.Errorf("Clone() = %v", got)
+	}
+	got.Tags[0] = "y"
+	got.Attrs["k"].A = 2
+	got.Any.([]int)[0] = 2
+	if src.Tags[0] != "x" || src.Attrs["k"].A != 1 || src.Any.([]int)[0] != 1 {
+		t.Errorf("Clone() shares memory with source")
+	}
+}
+
+func TestGetField(t *testing.T) {
+	obj := FooFieldTest{A0: 11, A: "12"}
+	got, err := GetField[int](obj, "A0")
+	if err != nil || got != 11 {
+		t.Errorf("GetField() = %v, %v", got, err)
+	}
+	conv, err := GetField[int64](&obj, "a")
+	if err != nil || conv != 12 {
+		t.Errorf("GetField() = %v, %v", conv, err)
+	}
+	if _, err := GetField[int](obj, "not_exist"); err == nil {
+		t.Errorf("GetField() want error")
+	}
+}
@@ -48,6 +48,9 @@ func getStructFieldNames(target reflect.Value, arg *args) []string {
 		out := make([]string, 0, num)
 		for i := 0; i < num; i++ {
 			fieldTp := target.Type().Field(i)
+			if isUnexportedField(fieldTp) {
+				continue
+			}
 			if fieldTp.Anonymous {
 				if names := getStructFieldNames(target.Field(i), arg); len(names) > 0 {
 					out = append(out, names...)
@@ -91,7 +94,7 @@ func GetFieldsValue(target interface{}, omitempty bool, fieldType FieldType, ign
 	arg.omitempty = omitempty
 	arg.ignoreFieldMap = ignoresMap
 
-	return getStructFieldValues(instVl, &arg)
+	return getStructFieldValues(addressableValue(instVl), &arg)
 }
 
 func getStructFieldValues(target reflect.Value, arg *args) []interface{} {
@@ -100,7 +103,10 @@ func getStructFieldValues(target reflect.Value, arg *args) []interface{} {
 		out := make([]interface{}, 0, num)
 		for i := 0; i < num; i++ {
 			fieldTp := target.Type().Field(i)
-			fieldVl := target.Field(i)
+			fieldVl, ok := accessibleField(target.Field(i), fieldTp, arg)
+			if !ok {
+				continue
+			}
 
 			if fieldTp.Anonymous {
 				if values := getStructFieldValues(fieldVl, arg); len(values) > 0 {
@@ -198,43 +204,35 @@ func GetNotZeroFields(target interface{}) []string {
 
 // GetFieldValue 获取struct对象的字段值
 // fieldOrTagName可以是字段名，json/gorm/xorm tag, 或小驼峰字段名
+// 也可以是路径，如 profile.addresses[0].city, tags["env"]，逐层经过结构体、指针、map和slice
+// 路径不存在时返回nil
 func GetFieldValue(target interface{}, fieldOrTagName string, opts ...CopyOption) interface{} {
 	if target == nil {
 		return nil
 	}
 	optArgs := newOpts(opts...)
 
-	inst := reflect.ValueOf(target)
-	if inst.Kind() == reflect.Ptr {
-		inst = inst.Elem()
-	}
-	if inst.Kind() == reflect.Struct {
-		return getFileValue(inst, fieldOrTagName, &optArgs)
+	field, err := lookupPath(reflect.ValueOf(target), fieldOrTagName, &optArgs)
+	if err != nil || !field.CanInterface() {
+		return nil
 	}
-	return nil
+	return field.Interface()
 }
 
-func getFileValue(from reflect.Value, fieldOrTagName string, optArgs *args) interface{} {
-	field := from.FieldByName(fieldOrTagName)
-	// 直接字段名获取成功
-	if field.IsValid() {
-		return field.Interface()
+// lookupPath 按路径读取字段，不会分配中间的nil指针
+func lookupPath(inst reflect.Value, path string, optArgs *args) (reflect.Value, error) {
+	segs, err := parsePath(path)
+	if err != nil {
+		return reflect.Value{}, err
 	}
-	// 通过 json/gorm/xorm tag 或小驼峰字段名获取
-	for i := 0; i < from.NumField(); i++ {
-		field = from.Field(i)
-		fieldType := from.Type().Field(i)
-		fieldName, _, _ := getFieldTag(fieldType, optArgs)
-		if fieldName == fieldOrTagName {
-			return field.Interface()
-		}
-	}
-	return nil
+	return pathWalker{optArgs: optArgs}.get(inst, segs)
 }
 
 // SetFieldValue 对struct（必须为指针） 对象，设置对应字段的变量
 // fieldOrTagName可以是字段名，json/gorm/xorm tag, 或小驼峰字段名
+// 也可以是路径，如 profile.addresses[0].city, tags["env"]，中间的nil指针和map会自动分配
 // 如果字段的类型和值的类型对不上，则设置的是0值，不返回错误
+// 单个字段名不存在时不做处理；路径无法解析时返回错误
 func SetFieldValue(target interface{}, fieldOrTagName string, value interface{}, opts ...CopyOption) (err error) {
 	if target == nil {
 		return
@@ -246,40 +244,27 @@ func SetFieldValue(target interface{}, fieldOrTagName string, value interface{},
 		err = fmt.Errorf("not pointer target")
 		return
 	}
-	inst = inst.Elem()
-	if inst.Kind() == reflect.Struct {
-		defer func() {
-			if r := recover(); r != nil {
-				err = fmt.Errorf("set field value err=[%v]", r)
-			}
-		}()
-		err = setFieldValue(inst, fieldOrTagName, value, &optArgs)
-	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("set field value err=[%v]", r)
+		}
+	}()
+	err = setFieldValue(inst.Elem(), fieldOrTagName, value, &optArgs)
 	return
 }
 
 func setFieldValue(from reflect.Value, fieldOrTagName string, value interface{}, optArgs *args) error {
-
-	field := from.FieldByName(fieldOrTagName)
-
-	if field.IsValid() {
-		if field.Kind() == reflect.Ptr {
-			field = field.Elem()
-		}
-		return valueDeepCopy(field, value, 0, fieldOrTagName, optArgs)
+	segs, err := parsePath(fieldOrTagName)
+	if err != nil {
+		return err
 	}
-
-	// 通过 json/gorm/xorm tag 或小驼峰字段名获取
-	for i := 0; i < from.NumField(); i++ {
-		field = from.Field(i)
-		fieldType := from.Type().Field(i)
-		fieldName, _, _ := getFieldTag(fieldType, optArgs)
-		if fieldName == fieldOrTagName {
-			if field.Kind() == reflect.Ptr {
-				field = field.Elem()
-			}
-			return valueDeepCopy(field, value, 0, fieldOrTagName, optArgs)
+	w := pathWalker{optArgs: optArgs}
+	if len(segs) == 1 && from.Kind() == reflect.Struct {
+		if _, _, ok := w.fieldIndex(from.Type(), segs[0]); !ok {
+			return nil
 		}
 	}
-	return nil
-}
\ No newline at end of file
+	return w.modify(from, segs, func(field reflect.Value) error {
+		return assignValue(field, value, fieldOrTagName, optArgs)
+	})
+}
@@ -0,0 +1,305 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: merge3.go
+ * @time: 2026/10/20 14:10
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// MergeSide 冲突时采用的一方
+type MergeSide int8
+
+const (
+	MergeSide_None   MergeSide = 0 + iota // 未解决，保留ours的值
+	MergeSide_Ours                        // 采用ours
+	MergeSide_Theirs                      // 采用theirs
+)
+
+// Conflict 三方合并中ours和theirs都修改了且结果不同的地方
+type Conflict struct {
+	Path       string      // 按tag字段名拼接的路径，同 Change.Path
+	Base       interface{} // 按 InstanceToMap 的规则转换，下同
+	Ours       interface{}
+	Theirs     interface{}
+	Resolution MergeSide // 解决冲突时采用的一方
+}
+
+// ConflictResolver 决定冲突采用哪一方，ours/theirs为传入 Merge3 的对象
+type ConflictResolver func(c Conflict, ours, theirs interface{}) MergeSide
+
+// WithConflictResolver Merge3时解决冲突的方式，默认不解决
+func WithConflictResolver(resolver ConflictResolver) CopyOption {
+	return func(a *args) {
+		a.resolver = resolver
+	}
+}
+
+// ResolveOurs 冲突时总是采用ours
+func ResolveOurs(Conflict, interface{}, interface{}) MergeSide {
+	return MergeSide_Ours
+}
+
+// ResolveTheirs 冲突时总是采用theirs
+func ResolveTheirs(Conflict, interface{}, interface{}) MergeSide {
+	return MergeSide_Theirs
+}
+
+// ResolveNewest 冲突时采用timeField较新的一方，timeField为 GetFieldValue 支持的字段名或路径
+// 字段可以是time.Time(或指针)，时间戳或 WithTimeFormatStr 格式的字符串，时间相同或无法获取时不解决
+func ResolveNewest(timeField string, opts ...CopyOption) ConflictResolver {
+	return func(_ Conflict, ours, theirs interface{}) MergeSide {
+		o, ok1 := fieldTime(GetFieldValue(ours, timeField), opts...)
+		t, ok2 := fieldTime(GetFieldValue(theirs, timeField), opts...)
+		switch {
+		case !ok1 || !ok2 || o.Equal(t):
+			return MergeSide_None
+		case o.After(t):
+			return MergeSide_Ours
+		default:
+			return MergeSide_Theirs
+		}
+	}
+}
+
+func fieldTime(v interface{}, opts ...CopyOption) (time.Time, bool) {
+	switch t := v.(type) {
+	case nil:
+		return time.Time{}, false
+	case time.Time:
+		return t, true
+	case *time.Time:
+		if t == nil {
+			return time.Time{}, false
+		}
+		return *t, true
+	}
+	if isNumberValue(v) {
+		return time.Unix(interface2Int64(v), 0), true
+	}
+	return ToTime(v, opts...)
+}
+
+// Merge3 以base为共同祖先，把ours和theirs的修改合并到out(必须为指针)
+// base/ours/theirs与out的类型一致(可以是值或指针)，base可以为nil
+//   - 只有一方修改的字段采用修改的一方，双方修改结果相同时直接采用
+//   - 双方修改不同时，结构体、map和长度不变的slice逐个字段/元素合并，其他情况为冲突
+//   - 冲突按 WithConflictResolver 解决，未解决的保留ours的值
+//
+// 返回所有冲突(包括已解决的)，字段名按 WithFieldType 选择的tag获取
+func Merge3(base, ours, theirs, out interface{}, opts ...CopyOption) (conflicts []Conflict, err error) {
+	outVl := reflect.ValueOf(out)
+	if outVl.Kind() != reflect.Ptr || outVl.IsNil() {
+		return nil, errors.New("not pointer target")
+	}
+	tpe := outVl.Elem().Type()
+	optArgs := newOpts(opts...)
+	defer func() {
+		if r := recover(); r != nil {
+			conflicts, err = nil, fmt.Errorf("merge3 err=[%v]", r)
+			printLog(&optArgs, 0, r)
+		}
+	}()
+
+	values := make([]reflect.Value, 3)
+	for i, it := range []interface{}{base, ours, theirs} {
+		v := reflect.ValueOf(it)
+		for v.Kind() == reflect.Ptr && v.Type() != tpe && !v.IsNil() {
+			v = v.Elem()
+		}
+		if v.Kind() == reflect.Ptr && v.IsNil() && v.Type() != tpe {
+			v = reflect.Value{}
+		}
+		if v.IsValid() && v.Type() != tpe {
+			return nil, fmt.Errorf("merge3 type %s mismatch %s", v.Type(), tpe)
+		}
+		if !v.IsValid() && i > 0 {
+			return nil, errors.New("nil ours or theirs")
+		}
+		values[i] = addressableValue(v)
+	}
+
+	m := &merger{optArgs: &optArgs, ours: ours, theirs: theirs}
+	res := reflect.New(tpe).Elem()
+	cloneValue(res, values[1], newCloneState(), &optArgs)
+	if _, err = m.merge(res, values[0], values[1], values[2], ""); err != nil {
+		return nil, err
+	}
+	outVl.Elem().Set(res)
+	return m.conflicts, nil
+}
+
+type merger struct {
+	optArgs   *args
+	ours      interface{}
+	theirs    interface{}
+	conflicts []Conflict
+}
+
+// merge res为ours对应位置的副本(可设置)，返回合并后是否不存在(map中删除对应的key)
+func (m *merger) merge(res, base, ours, theirs reflect.Value, path string) (bool, error) {
+	if m.same(ours, theirs) || m.same(base, theirs) {
+		return !ours.IsValid(), nil
+	}
+	if m.same(base, ours) {
+		return m.assign(res, theirs), nil
+	}
+	b, o, t := mergeIndirect(base), mergeIndirect(ours), mergeIndirect(theirs)
+	if b.IsValid() && o.IsValid() && t.IsValid() && b.Type() == o.Type() && o.Type() == t.Type() {
+		switch o.Kind() {
+		case reflect.Struct:
+			if isNestedStruct(o.Type()) {
+				return false, m.into(res, func(r reflect.Value) error {
+					return m.mergeStruct(r, b, o, t, path)
+				})
+			}
+		case reflect.Map:
+			return false, m.into(res, func(r reflect.Value) error {
+				return m.mergeMap(r, b, o, t, path)
+			})
+		case reflect.Slice, reflect.Array:
+			if b.Len() == o.Len() && o.Len() == t.Len() {
+				return false, m.into(res, func(r reflect.Value) error {
+					for i := 0; i < o.Len(); i++ {
+						if _, err := m.merge(r.Index(i), b.Index(i), o.Index(i), t.Index(i), indexPath(path, i)); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+			}
+		}
+	}
+	return m.conflict(res, base, ours, theirs, path), nil
+}
+
+func (m *merger) mergeStruct(res, base, ours, theirs reflect.Value, path string) error {
+	for _, sf := range structFields(ours.Type(), m.optArgs) {
+		field, ok := exposeValue(fieldByIndex(res, sf.index))
+		if !ok || !field.IsValid() || !field.CanSet() {
+			continue
+		}
+		values := make([]reflect.Value, 3)
+		for i, it := range []reflect.Value{base, ours, theirs} {
+			values[i], _ = exposeValue(fieldByIndex(it, sf.index))
+		}
+		if _, err := m.merge(field, values[0], values[1], values[2], keyPath(path, sf.name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *merger) mergeMap(res, base, ours, theirs reflect.Value, path string) error {
+	keys := map[string]reflect.Value{}
+	for _, mp := range []reflect.Value{base, ours, theirs} {
+		for name, k := range mapKeysByName(mp) {
+			keys[name] = k
+		}
+	}
+	for _, name := range sortedValueKeys(keys) {
+		k := keys[name]
+		elem := reflect.New(res.Type().Elem()).Elem()
+		if it := res.MapIndex(k); it.IsValid() {
+			elem.Set(it)
+		}
+		absent, err := m.merge(elem, base.MapIndex(k), ours.MapIndex(k), theirs.MapIndex(k), keyPath(path, name))
+		if err != nil {
+			return err
+		}
+		if absent {
+			res.SetMapIndex(k, reflect.Value{})
+			continue
+		}
+		if res.IsNil() { // ours为nil的map
+			res.Set(reflect.MakeMapWithSize(res.Type(), len(keys)))
+		}
+		res.SetMapIndex(k, elem)
+	}
+	return nil
+}
+
+// into 去掉res的指针/interface后修改，interface中的值修改副本后写回
+func (m *merger) into(res reflect.Value, fn func(r reflect.Value) error) error {
+	switch res.Kind() {
+	case reflect.Ptr:
+		return m.into(res.Elem(), fn)
+	case reflect.Interface:
+		elem := reflect.New(res.Elem().Type()).Elem()
+		elem.Set(res.Elem())
+		if err := m.into(elem, fn); err != nil {
+			return err
+		}
+		res.Set(elem)
+		return nil
+	}
+	return fn(res)
+}
+
+// conflict 记录冲突并按resolver解决
+func (m *merger) conflict(res, base, ours, theirs reflect.Value, path string) bool {
+	c := Conflict{Path: path, Base: m.generic(base), Ours: m.generic(ours), Theirs: m.generic(theirs)}
+	if m.optArgs.resolver != nil {
+		c.Resolution = m.optArgs.resolver(c, m.ours, m.theirs)
+	}
+	m.conflicts = append(m.conflicts, c)
+	if c.Resolution == MergeSide_Theirs {
+		return m.assign(res, theirs)
+	}
+	return !ours.IsValid()
+}
+
+// assign 把src深度拷贝到res，src不存在时置为0值并返回true
+func (m *merger) assign(res, src reflect.Value) bool {
+	if !src.IsValid() {
+		res.Set(reflect.Zero(res.Type()))
+		return true
+	}
+	it := reflect.New(src.Type()).Elem()
+	cloneValue(it, addressableValue(src), newCloneState(), m.optArgs)
+	res.Set(it)
+	return false
+}
+
+func (m *merger) same(a, b reflect.Value) bool {
+	d := &differ{optArgs: m.optArgs, nilAsEmpty: true, timeEqual: true}
+	d.diff(a, b, "", "")
+	return len(d.changes) == 0
+}
+
+func (m *merger) generic(v reflect.Value) interface{} {
+	return (&differ{optArgs: m.optArgs}).generic(v)
+}
+
+// mergeIndirect 去掉指针和interface，nil返回无效值
+func mergeIndirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return addressableValue(v)
+}
+
+func sortedValueKeys(keys map[string]reflect.Value) []string {
+	out := make([]string, 0, len(keys))
+	for k := range keys {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
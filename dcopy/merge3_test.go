@@ -0,0 +1,169 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: merge3_test.go
+ * @time: 2026/10/20 14:10
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type merge3Address struct {
+	City string `json:"city"`
+	Zip  string `json:"zip"`
+}
+
+type merge3Doc struct {
+	Title     string            `json:"title"`
+	Body      string            `json:"body"`
+	Address   *merge3Address    `json:"address"`
+	Labels    map[string]string `json:"labels"`
+	Tags      []string          `json:"tags"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+func newMerge3Doc() merge3Doc {
+	return merge3Doc{
+		Title:     "t",
+		Body:      "b",
+		Address:   &merge3Address{City: "xm", Zip: "361000"},
+		Labels:    map[string]string{"a": "1", "b": "2"},
+		Tags:      []string{"x", "y"},
+		UpdatedAt: time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestMerge3(t *testing.T) {
+	tests := []struct {
+		name          string
+		ours          func(d *merge3Doc)
+		theirs        func(d *merge3Doc)
+		opts          []CopyOption
+		want          func(d *merge3Doc)
+		wantConflicts []Conflict
+	}{
+		{
+			name:   "TestMerge3_disjoint",
+			ours:   func(d *merge3Doc) { d.Title = "ours"; d.Address.City = "sh"; delete(d.Labels, "a") },
+			theirs: func(d *merge3Doc) { d.Body = "theirs"; d.Address.Zip = "200000"; d.Labels["c"] = "3" },
+			want: func(d *merge3Doc) {
+				d.Title, d.Body = "ours", "theirs"
+				d.Address = &merge3Address{City: "sh", Zip: "200000"}
+				d.Labels = map[string]string{"b": "2", "c": "3"}
+			},
+		},
+		{
+			name:   "TestMerge3_sameChange",
+			ours:   func(d *merge3Doc) { d.Title = "new"; d.Tags = []string{"z"} },
+			theirs: func(d *merge3Doc) { d.Title = "new"; d.Tags = []string{"z"} },
+			want:   func(d *merge3Doc) { d.Title = "new"; d.Tags = []string{"z"} },
+		},
+		{
+			name:   "TestMerge3_conflict",
+			ours:   func(d *merge3Doc) { d.Title = "ours"; d.Labels["a"] = "o"; d.Tags = []string{"o"} },
+			theirs: func(d *merge3Doc) { d.Title = "theirs"; d.Labels["a"] = "t"; d.Tags = []string{"t", "u"} },
+			want:   func(d *merge3Doc) { d.Title = "ours"; d.Labels["a"] = "o"; d.Tags = []string{"o"} },
+			wantConflicts: []Conflict{
+				{Path: "title", Base: "t", Ours: "ours", Theirs: "theirs"},
+				{Path: "labels.a", Base: "1", Ours: "o", Theirs: "t"},
+				{Path: "tags", Base: []interface{}{"x", "y"}, Ours: []interface{}{"o"}, Theirs: []interface{}{"t", "u"}},
+			},
+		},
+		{
+			name:   "TestMerge3_theirs",
+			ours:   func(d *merge3Doc) { d.Tags[1] = "o"; d.Address.City = "o" },
+			theirs: func(d *merge3Doc) { d.Tags[1] = "t"; d.Address = nil },
+			opts:   []CopyOption{WithConflictResolver(ResolveTheirs)},
+			want:   func(d *merge3Doc) { d.Tags[1] = "t"; d.Address = nil },
+			wantConflicts: []Conflict{
+				{Path: "address", Base: map[string]interface{}{"city": "xm", "zip": "361000"}, Ours: map[string]interface{}{"city": "o", "zip": "361000"}, Resolution: MergeSide_Theirs},
+				{Path: "tags[1]", Base: "y", Ours: "o", Theirs: "t", Resolution: MergeSide_Theirs},
+			},
+		},
+		{
+			name:   "TestMerge3_deleteConflict",
+			ours:   func(d *merge3Doc) { d.Labels["a"] = "o" },
+			theirs: func(d *merge3Doc) { delete(d.Labels, "a") },
+			opts:   []CopyOption{WithConflictResolver(ResolveTheirs)},
+			want:   func(d *merge3Doc) { delete(d.Labels, "a") },
+			wantConflicts: []Conflict{
+				{Path: "labels.a", Base: "1", Ours: "o", Resolution: MergeSide_Theirs},
+			},
+		},
+		{
+			name:   "TestMerge3_nilMap",
+			ours:   func(d *merge3Doc) { d.Labels = nil },
+			theirs: func(d *merge3Doc) { d.Labels["c"] = "3" },
+			want:   func(d *merge3Doc) { d.Labels = map[string]string{"c": "3"} },
+		},
+		{
+			name: "TestMerge3_newest",
+			ours: func(d *merge3Doc) { d.Title = "ours"; d.UpdatedAt = d.UpdatedAt.Add(time.Hour) },
+			theirs: func(d *merge3Doc) {
+				d.Title = "theirs"
+				d.Body = "theirs"
+				d.UpdatedAt = d.UpdatedAt.Add(2 * time.Hour)
+			},
+			opts: []CopyOption{WithConflictResolver(ResolveNewest("updated_at")), WithTimeValType(TimeValType_Int64)},
+			want: func(d *merge3Doc) {
+				d.Title, d.Body = "theirs", "theirs"
+				d.UpdatedAt = d.UpdatedAt.Add(2 * time.Hour)
+			},
+			wantConflicts: []Conflict{
+				{Path: "title", Base: "t", Ours: "ours", Theirs: "theirs", Resolution: MergeSide_Theirs},
+				{Path: "updated_at", Base: int64(1792396800), Ours: int64(1792400400), Theirs: int64(1792404000), Resolution: MergeSide_Theirs},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := newMerge3Doc()
+			ours, theirs, want := Clone(base), Clone(base), Clone(base)
+			tt.ours(&ours)
+			tt.theirs(&theirs)
+			tt.want(&want)
+			var out merge3Doc
+			conflicts, err := Merge3(base, &ours, theirs, &out, tt.opts...)
+			if err != nil {
+				t.Fatalf("Merge3() error = %v", err)
+			}
+			if !reflect.DeepEqual(out, want) {
+				t.Errorf("Merge3() out = %+v, want %+v", out, want)
+			}
+			if !reflect.DeepEqual(conflicts, tt.wantConflicts) {
+				t.Errorf("Merge3() conflicts = %#v, want %#v", conflicts, tt.wantConflicts)
+			}
+			if !reflect.DeepEqual(base, newMerge3Doc()) {
+				t.Errorf("Merge3() modified base")
+			}
+		})
+	}
+}
+
+func TestMerge3Error(t *testing.T) {
+	var out merge3Doc
+	if _, err := Merge3(nil, merge3Doc{}, merge3Doc{}, out); err == nil {
+		t.Errorf("Merge3() want error for non-pointer out")
+	}
+	if _, err := Merge3(nil, merge3Doc{}, 1, &out); err == nil {
+		t.Errorf("Merge3() want error for type mismatch")
+	}
+	if _, err := Merge3(nil, nil, merge3Doc{}, &out); err == nil {
+		t.Errorf("Merge3() want error for nil ours")
+	}
+	ours := newMerge3Doc()
+	conflicts, err := Merge3(nil, ours, ours, &out)
+	if err != nil || len(conflicts) != 0 || !reflect.DeepEqual(out, ours) {
+		t.Errorf("Merge3() = %+v, %v, %v", out, conflicts, err)
+	}
+}
@@ -0,0 +1,221 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: merge_patch.go
+ * @time: 2026/10/19 23:30
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ApplyMergePatch 把 JSON Merge Patch(RFC 7386) 合并到ptr指向的对象上
+// patch可以是json的[]byte/string，或已解析的 map[string]interface{}
+//   - null 删除map的key，结构体字段置为0值
+//   - 对象递归合并，结构体字段按 WithFieldType 选择的tag字段名匹配，未知字段忽略
+//   - 数组及其他值整体替换，按 InstanceFromMap 的规则转换
+//
+// 返回发生变化的字段路径(如 profile.nickname)；失败时对象保持不变
+func ApplyMergePatch(ptr interface{}, patch interface{}, opts ...CopyOption) (changed []string, err error) {
+	inst := reflect.ValueOf(ptr)
+	if inst.Kind() != reflect.Ptr || inst.IsNil() {
+		return nil, errors.New("not pointer target")
+	}
+	value, err := mergePatchValue(patch)
+	if err != nil {
+		return nil, err
+	}
+	optArgs := newOpts(opts...)
+	defer func() {
+		if r := recover(); r != nil {
+			changed, err = nil, fmt.Errorf("apply merge patch err=[%v]", r)
+			printLog(&optArgs, 0, r)
+		}
+	}()
+
+	work := reflect.New(inst.Elem().Type()).Elem()
+	cloneValue(work, inst.Elem(), newCloneState(), &optArgs)
+	m := &mergePatcher{w: pathWalker{optArgs: &optArgs, tagOnly: true}}
+	if value == nil {
+		m.zero(work, "")
+	} else if err = m.merge(work, value, ""); err != nil {
+		return nil, err
+	}
+	inst.Elem().Set(work)
+	return m.changed, nil
+}
+
+// mergePatchValue 解析merge patch
+func mergePatchValue(patch interface{}) (interface{}, error) {
+	var data []byte
+	switch p := patch.(type) {
+	case map[string]interface{}:
+		return p, nil
+	case []byte:
+		data = p
+	case json.RawMessage:
+		data = p
+	case string:
+		data = []byte(p)
+	default:
+		return nil, fmt.Errorf("unsupported merge patch type %T", patch)
+	}
+	var out interface{}
+	if err := decodeJSONNumber(data, &out); err != nil {
+		return nil, fmt.Errorf("bad merge patch: %v", err)
+	}
+	return out, nil
+}
+
+// mergePatcher 执行merge patch并记录变化的字段路径
+type mergePatcher struct {
+	w       pathWalker
+	changed []string
+}
+
+func (m *mergePatcher) merge(dest reflect.Value, patch interface{}, path string) error {
+	obj, ok := patch.(map[string]interface{})
+	if !ok {
+		return m.replace(dest, patch, path)
+	}
+	switch dest.Kind() {
+	case reflect.Ptr:
+		if isNestedStruct(dest.Type()) || dest.Type().Elem().Kind() == reflect.Map {
+			if dest.IsNil() {
+				dest.Set(reflect.New(dest.Type().Elem()))
+			}
+			return m.merge(dest.Elem(), obj, path)
+		}
+	case reflect.Interface:
+		// interface{}中的对象按 map[string]interface{} 合并，不是对象时按空对象合并
+		mp := map[string]interface{}{}
+		if old, ok := dest.Interface().(map[string]interface{}); ok {
+			for k, v := range old {
+				mp[k] = v
+			}
+		}
+		target := reflect.ValueOf(&mp).Elem()
+		if err := m.mergeMap(target, obj, path); err != nil {
+			return err
+		}
+		dest.Set(target)
+		return nil
+	case reflect.Struct:
+		if isNestedStruct(dest.Type()) {
+			return m.mergeStruct(dest, obj, path)
+		}
+	case reflect.Map:
+		return m.mergeMap(dest, obj, path)
+	}
+	return m.replace(dest, stripMergeNulls(obj), path)
+}
+
+func (m *mergePatcher) mergeStruct(dest reflect.Value, obj map[string]interface{}, path string) error {
+	for _, name := range sortedKeys(obj) {
+		field, ok := m.w.field(dest, name, true)
+		if !ok || !field.CanSet() {
+			continue
+		}
+		fieldPath := joinFieldPath(path, name)
+		if obj[name] == nil {
+			m.zero(field, fieldPath)
+			continue
+		}
+		if err := m.merge(field, obj[name], fieldPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mergePatcher) mergeMap(dest reflect.Value, obj map[string]interface{}, path string) error {
+	for _, name := range sortedKeys(obj) {
+		key, err := pathMapKey(dest.Type().Key(), name, m.w.optArgs)
+		if err != nil {
+			return err
+		}
+		fieldPath := joinFieldPath(path, name)
+		old := dest.MapIndex(key)
+		if obj[name] == nil {
+			if old.IsValid() {
+				dest.SetMapIndex(key, reflect.Value{})
+				m.changed = append(m.changed, fieldPath)
+			}
+			continue
+		}
+		elem := reflect.New(dest.Type().Elem()).Elem()
+		if old.IsValid() {
+			elem.Set(old)
+		}
+		count := len(m.changed)
+		if err := m.merge(elem, obj[name], fieldPath); err != nil {
+			return err
+		}
+		// 新增的key即使是0值也算变化
+		if !old.IsValid() && len(m.changed) == count {
+			m.changed = append(m.changed, fieldPath)
+		}
+		if dest.IsNil() {
+			dest.Set(reflect.MakeMap(dest.Type()))
+		}
+		dest.SetMapIndex(key, elem)
+	}
+	return nil
+}
+
+// replace 整体替换，值不同时记录变化
+func (m *mergePatcher) replace(dest reflect.Value, value interface{}, path string) error {
+	it := reflect.New(dest.Type()).Elem()
+	if err := assignValue(it, value, path, m.w.optArgs); err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(dest.Interface(), it.Interface()) {
+		dest.Set(it)
+		m.changed = append(m.changed, path)
+	}
+	return nil
+}
+
+// zero 置为0值，原来不是0值时记录变化
+func (m *mergePatcher) zero(dest reflect.Value, path string) {
+	if !dest.IsZero() {
+		dest.Set(reflect.Zero(dest.Type()))
+		m.changed = append(m.changed, path)
+	}
+}
+
+// stripMergeNulls 合并到非对象上时等同于合并到空对象，去掉所有的null
+func stripMergeNulls(obj map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		switch it := v.(type) {
+		case nil:
+			continue
+		case map[string]interface{}:
+			out[k] = stripMergeNulls(it)
+		default:
+			out[k] = v
+		}
+	}
+	return out
+}
+
+func sortedKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
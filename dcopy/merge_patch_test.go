@@ -0,0 +1,149 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: merge_patch_test.go
+ * @time: 2026/10/19 23:30
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type mergeProfile struct {
+	Nickname string   `json:"nickname"`
+	Age      int      `json:"age"`
+	Tags     []string `json:"tags"`
+}
+
+type mergeUser struct {
+	Name     string                 `json:"name"`
+	Profile  *mergeProfile          `json:"profile"`
+	Attrs    map[string]int         `json:"attrs"`
+	Extra    map[string]interface{} `json:"extra"`
+	Birthday time.Time              `json:"birthday"`
+}
+
+func newMergeUser() *mergeUser {
+	return &mergeUser{
+		Name:    "zgd",
+		Profile: &mergeProfile{Nickname: "z", Age: 30, Tags: []string{"a", "b"}},
+		Attrs:   map[string]int{"x": 1, "y": 2},
+		Extra:   map[string]interface{}{"k": "v", "obj": map[string]interface{}{"a": 1}},
+	}
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		patch       interface{}
+		check       func(u *mergeUser) interface{}
+		want        interface{}
+		wantChanged []string
+		wantErr     bool
+	}{
+		{
+			name:        "TestApplyMergePatch_merge",
+			patch:       []byte(`{"profile":{"age":"31","nickname":"z"}}`),
+			check:       func(u *mergeUser) interface{} { return *u.Profile },
+			want:        mergeProfile{Nickname: "z", Age: 31, Tags: []string{"a", "b"}},
+			wantChanged: []string{"profile.age"},
+		},
+		{
+			name:        "TestApplyMergePatch_arrayReplace",
+			patch:       `{"profile":{"tags":["c"]}}`,
+			check:       func(u *mergeUser) interface{} { return u.Profile.Tags },
+			want:        []string{"c"},
+			wantChanged: []string{"profile.tags"},
+		},
+		{
+			name:        "TestApplyMergePatch_nullField",
+			patch:       map[string]interface{}{"name": nil, "profile": nil},
+			check:       func(u *mergeUser) interface{} { return u.Name == "" && u.Profile == nil },
+			want:        true,
+			wantChanged: []string{"name", "profile"},
+		},
+		{
+			name:        "TestApplyMergePatch_mapKeys",
+			patch:       `{"attrs":{"x":null,"z":0,"y":"5"}}`,
+			check:       func(u *mergeUser) interface{} { return u.Attrs },
+			want:        map[string]int{"y": 5, "z": 0},
+			wantChanged: []string{"attrs.x", "attrs.y", "attrs.z"},
+		},
+		{
+			name:  "TestApplyMergePatch_interface",
+			patch: `{"extra":{"obj":{"a":null,"b":{"c":null,"d":1}},"k":null}}`,
+			check: func(u *mergeUser) interface{} {
+				return jsonEqual(u.Extra, map[string]interface{}{"obj": map[string]interface{}{"b": map[string]interface{}{"d": 1}}})
+			},
+			want:        true,
+			wantChanged: []string{"extra.k", "extra.obj.a", "extra.obj.b.d"},
+		},
+		{
+			name:        "TestApplyMergePatch_time",
+			patch:       `{"birthday":"2026-10-19 08:00:00"}`,
+			check:       func(u *mergeUser) interface{} { return u.Birthday.Format("2006-01-02") },
+			want:        "2026-10-19",
+			wantChanged: []string{"birthday"},
+		},
+		{
+			name:        "TestApplyMergePatch_unknown",
+			patch:       `{"none":1,"name":"zgd"}`,
+			check:       func(u *mergeUser) interface{} { return u.Name },
+			want:        "zgd",
+			wantChanged: nil,
+		},
+		{
+			name:    "TestApplyMergePatch_badJSON",
+			patch:   `{"name":`,
+			wantErr: true,
+		},
+		{
+			name:    "TestApplyMergePatch_badType",
+			patch:   1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newMergeUser()
+			changed, err := ApplyMergePatch(u, tt.patch)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ApplyMergePatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !reflect.DeepEqual(u, newMergeUser()) {
+					t.Errorf("ApplyMergePatch() modified target on error: %+v", u)
+				}
+				return
+			}
+			if got := tt.check(u); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ApplyMergePatch() got %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(changed, tt.wantChanged) {
+				t.Errorf("ApplyMergePatch() changed = %v, want %v", changed, tt.wantChanged)
+			}
+		})
+	}
+}
+
+func TestApplyMergePatchMapKey(t *testing.T) {
+	obj := &struct {
+		Scores map[int]float64 `json:"scores"`
+	}{}
+	if _, err := ApplyMergePatch(obj, `{"scores":{"x":1}}`); err == nil {
+		t.Errorf("ApplyMergePatch() want error for bad map key")
+	}
+	changed, err := ApplyMergePatch(obj, `{"scores":{"7":1.5}}`)
+	if err != nil || obj.Scores[7] != 1.5 || !reflect.DeepEqual(changed, []string{"scores.7"}) {
+		t.Errorf("ApplyMergePatch() = %v, %v, %v", obj.Scores, changed, err)
+	}
+}
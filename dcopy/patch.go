@@ -0,0 +1,167 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: patch.go
+ * @time: 2026/10/19 23:00
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// patchOperation JSON Patch 中的一个操作
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  *string         `json:"path"`
+	From  *string         `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// ApplyJSONPatch 把 JSON Patch(RFC 6902) 直接应用到ptr指向的对象上
+// 支持 add/remove/replace/move/copy/test，路径为 JSON Pointer，结构体字段按 WithFieldType 选择的tag字段名匹配
+// 值按 InstanceFromMap 的规则转换，数字按 UseNumber 处理
+// 在副本上依次执行，任何一个操作失败时返回错误，对象保持不变
+func ApplyJSONPatch(ptr interface{}, patch []byte, opts ...CopyOption) (err error) {
+	inst := reflect.ValueOf(ptr)
+	if inst.Kind() != reflect.Ptr || inst.IsNil() {
+		return errors.New("not pointer target")
+	}
+	var ops []patchOperation
+	if err = decodeJSONNumber(patch, &ops); err != nil {
+		return fmt.Errorf("bad json patch: %v", err)
+	}
+	optArgs := newOpts(opts...)
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("apply json patch err=[%v]", r)
+			printLog(&optArgs, 0, r)
+		}
+	}()
+
+	work := reflect.New(inst.Elem().Type()).Elem()
+	cloneValue(work, inst.Elem(), newCloneState(), &optArgs)
+	w := pathWalker{optArgs: &optArgs, tagOnly: true}
+	for i, op := range ops {
+		if err = applyPatchOperation(w, work, op); err != nil {
+			return fmt.Errorf("json patch operation %d (%s): %v", i, op.Op, err)
+		}
+	}
+	inst.Elem().Set(work)
+	return nil
+}
+
+func applyPatchOperation(w pathWalker, root reflect.Value, op patchOperation) error {
+	if op.Path == nil {
+		return errors.New("missing path")
+	}
+	segs, err := parsePointer(*op.Path)
+	if err != nil {
+		return err
+	}
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return errors.New("missing value")
+		}
+		var value interface{}
+		if err := decodeJSONNumber(op.Value, &value); err != nil {
+			return err
+		}
+		switch op.Op {
+		case "add":
+			return w.add(root, segs, value, *op.Path)
+		case "replace":
+			if _, err := w.get(root, segs); err != nil {
+				return err
+			}
+			return w.modify(root, segs, func(field reflect.Value) error {
+				return assignValue(field, value, *op.Path, w.optArgs)
+			})
+		default:
+			field, err := w.get(root, segs)
+			if err != nil {
+				return err
+			}
+			got, err := toGenericValue(field, w.optArgs)
+			if err != nil {
+				return err
+			}
+			if !jsonEqual(got, value) {
+				return fmt.Errorf("test %s failed: got %v, want %v", *op.Path, got, value)
+			}
+			return nil
+		}
+	case "remove":
+		return w.removePath(root, segs)
+	case "move", "copy":
+		if op.From == nil {
+			return errors.New("missing from")
+		}
+		fromSegs, err := parsePointer(*op.From)
+		if err != nil {
+			return err
+		}
+		field, err := w.get(root, fromSegs)
+		if err != nil {
+			return err
+		}
+		value := reflect.New(field.Type()).Elem()
+		cloneValue(value, addressableValue(field), newCloneState(), w.optArgs)
+		if op.Op == "move" {
+			if *op.From == *op.Path {
+				return nil
+			}
+			if isPathPrefix(fromSegs, segs) {
+				return fmt.Errorf("cannot move %s into its child %s", *op.From, *op.Path)
+			}
+			if err := w.removePath(root, fromSegs); err != nil {
+				return err
+			}
+		}
+		return w.add(root, segs, value.Interface(), *op.Path)
+	}
+	return fmt.Errorf("unsupported op %q", op.Op)
+}
+
+// isPathPrefix prefix是否为segs的上级路径
+func isPathPrefix(prefix, segs []string) bool {
+	if len(prefix) >= len(segs) {
+		return false
+	}
+	for i, seg := range prefix {
+		if segs[i] != seg {
+			return false
+		}
+	}
+	return true
+}
+
+// decodeJSONNumber 按 UseNumber 解析json
+func decodeJSONNumber(data []byte, out interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(out)
+}
+
+// jsonEqual 两个通用数据序列化成json后是否一致，数字不区分 1 和 1.0
+func jsonEqual(a, b interface{}) bool {
+	var x, y interface{}
+	if data, err := json.Marshal(a); err != nil || json.Unmarshal(data, &x) != nil {
+		return false
+	}
+	if data, err := json.Marshal(b); err != nil || json.Unmarshal(data, &y) != nil {
+		return false
+	}
+	return reflect.DeepEqual(x, y)
+}
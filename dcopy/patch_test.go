@@ -0,0 +1,141 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: patch_test.go
+ * @time: 2026/10/19 23:00
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyJSONPatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		patch   string
+		check   func(doc *pointerDoc) interface{}
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name:  "TestApplyJSONPatch_addInsert",
+			patch: `[{"op":"add","path":"/orders/0/items/1","value":{"sku":"c","qty":"3"}}]`,
+			check: func(doc *pointerDoc) interface{} {
+				return []string{doc.Orders[0].Items[0].Sku, doc.Orders[0].Items[1].Sku, doc.Orders[0].Items[2].Sku}
+			},
+			want: []string{"a", "c", "b"},
+		},
+		{
+			name:  "TestApplyJSONPatch_addAppend",
+			patch: `[{"op":"add","path":"/orders/-","value":{"id":9007199254740993}}]`,
+			check: func(doc *pointerDoc) interface{} { return doc.Orders[1].ID },
+			want:  int64(9007199254740993),
+		},
+		{
+			name:  "TestApplyJSONPatch_addKey",
+			patch: `[{"op":"add","path":"/attrs/new","value":"v"}]`,
+			check: func(doc *pointerDoc) interface{} { return doc.Attrs["new"] },
+			want:  "v",
+		},
+		{
+			name:  "TestApplyJSONPatch_remove",
+			patch: `[{"op":"remove","path":"/orders/0/items/0"}]`,
+			check: func(doc *pointerDoc) interface{} { return doc.Orders[0].Items[0].Sku },
+			want:  "b",
+		},
+		{
+			name:  "TestApplyJSONPatch_replace",
+			patch: `[{"op":"replace","path":"/orders/0/items/1/qty","value":7}]`,
+			check: func(doc *pointerDoc) interface{} { return doc.Orders[0].Items[1].Qty },
+			want:  7,
+		},
+		{
+			name:  "TestApplyJSONPatch_move",
+			patch: `[{"op":"move","from":"/attrs/a~1b","path":"/attrs/ab"}]`,
+			check: func(doc *pointerDoc) interface{} { return doc.Attrs },
+			want:  map[string]string{"ab": "slash", "m~n": "tilde"},
+		},
+		{
+			name:  "TestApplyJSONPatch_copy",
+			patch: `[{"op":"copy","from":"/orders/0/items/0","path":"/orders/0/items/-"},{"op":"replace","path":"/orders/0/items/2/sku","value":"d"}]`,
+			check: func(doc *pointerDoc) interface{} {
+				return []string{doc.Orders[0].Items[0].Sku, doc.Orders[0].Items[2].Sku}
+			},
+			want: []string{"a", "d"},
+		},
+		{
+			name:  "TestApplyJSONPatch_copyConvert",
+			patch: `[{"op":"copy","from":"/orders/0/items/1","path":"/meta/item"}]`,
+			check: func(doc *pointerDoc) interface{} { return doc.Meta["item"] },
+			want:  &pointerItem{Sku: "b", Qty: 2},
+		},
+		{
+			name:  "TestApplyJSONPatch_test",
+			patch: `[{"op":"test","path":"/orders/0","value":{"id":1,"items":[{"sku":"a","qty":1},{"sku":"b","qty":2.0}]}},{"op":"remove","path":"/attrs"}]`,
+			check: func(doc *pointerDoc) interface{} { return doc.Attrs == nil },
+			want:  true,
+		},
+		{
+			name:    "TestApplyJSONPatch_testFailed",
+			patch:   `[{"op":"replace","path":"/orders/0/id","value":5},{"op":"test","path":"/orders/0/items/0/sku","value":"x"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "TestApplyJSONPatch_missingParent",
+			patch:   `[{"op":"add","path":"/attrs/a/b","value":1}]`,
+			wantErr: true,
+		},
+		{
+			name:    "TestApplyJSONPatch_replaceMissing",
+			patch:   `[{"op":"replace","path":"/attrs/none","value":1}]`,
+			wantErr: true,
+		},
+		{
+			name:    "TestApplyJSONPatch_moveIntoChild",
+			patch:   `[{"op":"move","from":"/orders/0","path":"/orders/0/items/0"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "TestApplyJSONPatch_unknownOp",
+			patch:   `[{"op":"drop","path":"/attrs"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "TestApplyJSONPatch_missingValue",
+			patch:   `[{"op":"add","path":"/attrs/x"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "TestApplyJSONPatch_badJSON",
+			patch:   `{"op":"add"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := newPointerDoc()
+			err := ApplyJSONPatch(doc, []byte(tt.patch))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ApplyJSONPatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				// 失败时对象保持不变
+				if !reflect.DeepEqual(doc, newPointerDoc()) {
+					t.Errorf("ApplyJSONPatch() modified target on error: %+v", doc)
+				}
+				return
+			}
+			if got := tt.check(doc); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ApplyJSONPatch() got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
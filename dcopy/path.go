@@ -0,0 +1,494 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: path.go
+ * @time: 2026/10/19 21:40
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// parsePath 解析字段路径，如 profile.addresses[0].city, tags["env"]
+// 每一段按所在的容器解释：结构体为字段名/tag，map为key，slice/array为下标
+func parsePath(path string) ([]string, error) {
+	if path == "" {
+		return nil, fmt.Errorf("empty path")
+	}
+	segs := make([]string, 0, 4)
+	for i := 0; i < len(path); {
+		switch path[i] {
+		case '.':
+			if i == 0 || i == len(path)-1 || path[i+1] == '.' || path[i+1] == '[' {
+				return nil, fmt.Errorf("path %q: empty segment at %d", path, i)
+			}
+			i++
+		case '[':
+			seg, n, err := parseBracket(path[i:])
+			if err != nil {
+				return nil, fmt.Errorf("path %q: %v", path, err)
+			}
+			segs = append(segs, seg)
+			i += n
+			if i < len(path) && path[i] != '.' && path[i] != '[' {
+				return nil, fmt.Errorf("path %q: unexpected %q at %d", path, path[i], i)
+			}
+		default:
+			end := strings.IndexAny(path[i:], ".[")
+			if end < 0 {
+				end = len(path) - i
+			}
+			segs = append(segs, path[i:i+end])
+			i += end
+		}
+	}
+	return segs, nil
+}
+
+// parseBracket 解析 [0], ["key"], ['key'] 或 [key]，返回段和消耗的长度
+func parseBracket(s string) (string, int, error) {
+	if len(s) > 1 && (s[1] == '"' || s[1] == '\'') {
+		quote := s[1]
+		for i := 2; i < len(s); i++ {
+			switch s[i] {
+			case '\\':
+				i++
+			case quote:
+				if i+1 >= len(s) || s[i+1] != ']' {
+					return "", 0, fmt.Errorf("missing ] after quoted key")
+				}
+				raw := s[1 : i+1]
+				if quote == '\'' {
+					raw = `"` + strings.ReplaceAll(strings.ReplaceAll(raw[1:len(raw)-1], `\'`, `'`), `"`, `\"`) + `"`
+				}
+				seg, err := strconv.Unquote(raw)
+				if err != nil {
+					return "", 0, fmt.Errorf("bad quoted key %s: %v", s[1:i+1], err)
+				}
+				return seg, i + 2, nil
+			}
+		}
+		return "", 0, fmt.Errorf("unterminated quoted key")
+	}
+	end := strings.IndexByte(s, ']')
+	if end < 0 {
+		return "", 0, fmt.Errorf("missing ]")
+	}
+	if end == 1 {
+		return "", 0, fmt.Errorf("empty []")
+	}
+	return s[1:end], end + 1, nil
+}
+
+// pathWalker 按解析后的路径访问值
+type pathWalker struct {
+	optArgs *args
+	tagOnly bool // 结构体字段只按 getFieldTag 的字段名匹配，用于JSON Pointer
+	grow    int  // modify时slice下标超出长度但小于grow时扩展slice，0表示不扩展
+}
+
+// errPathNotFound 路径中的字段或key不存在
+var errPathNotFound = errors.New("not found")
+
+// get 按路径逐段读取，不会分配中间的nil指针/map
+func (w pathWalker) get(inst reflect.Value, segs []string) (reflect.Value, error) {
+	for i, seg := range segs {
+		for inst.Kind() == reflect.Ptr || inst.Kind() == reflect.Interface {
+			if inst.IsNil() {
+				return reflect.Value{}, fmt.Errorf("path %s: nil value", strings.Join(segs[:i], "."))
+			}
+			inst = inst.Elem()
+		}
+		inst = addressableValue(inst)
+		switch inst.Kind() {
+		case reflect.Struct:
+			field, ok := w.field(inst, seg, false)
+			if !ok {
+				return reflect.Value{}, fmt.Errorf("field %q %w in %s", seg, errPathNotFound, inst.Type())
+			}
+			inst = field
+		case reflect.Map:
+			key, err := pathMapKey(inst.Type().Key(), seg, w.optArgs)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			elem := inst.MapIndex(key)
+			if !elem.IsValid() {
+				return reflect.Value{}, fmt.Errorf("key %q %w in %s", seg, errPathNotFound, inst.Type())
+			}
+			inst = elem
+		case reflect.Slice, reflect.Array:
+			idx, err := pathIndex(seg, inst.Len())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			inst = inst.Index(idx)
+		default:
+			return reflect.Value{}, fmt.Errorf("cannot index %s with %q", inst.Type(), seg)
+		}
+	}
+	return inst, nil
+}
+
+// modify 按路径找到目标值后调用fn修改，inst必须可设置
+// 中间的nil指针/map会被分配，nil的interface{}按 map[string]interface{} 分配
+// map的元素不可寻址，修改副本后再写回；slice的下标为 - 时追加一个元素
+func (w pathWalker) modify(inst reflect.Value, segs []string, fn func(reflect.Value) error) error {
+	if len(segs) == 0 {
+		return fn(inst)
+	}
+	seg := segs[0]
+	switch inst.Kind() {
+	case reflect.Ptr:
+		if inst.IsNil() {
+			if !inst.CanSet() {
+				return fmt.Errorf("nil %s cannot be set", inst.Type())
+			}
+			inst.Set(reflect.New(inst.Type().Elem()))
+		}
+		return w.modify(inst.Elem(), segs, fn)
+	case reflect.Interface:
+		if !inst.CanSet() {
+			return fmt.Errorf("%s cannot be set", inst.Type())
+		}
+		var elem reflect.Value
+		if inst.IsNil() {
+			elem = reflect.ValueOf(&map[string]interface{}{}).Elem()
+		} else {
+			elem = reflect.New(inst.Elem().Type()).Elem()
+			elem.Set(inst.Elem())
+		}
+		if err := w.modify(elem, segs, fn); err != nil {
+			return err
+		}
+		inst.Set(elem)
+		return nil
+	case reflect.Struct:
+		field, ok := w.field(inst, seg, true)
+		if !ok {
+			return fmt.Errorf("field %q %w in %s", seg, errPathNotFound, inst.Type())
+		}
+		return w.modify(field, segs[1:], fn)
+	case reflect.Map:
+		key, err := pathMapKey(inst.Type().Key(), seg, w.optArgs)
+		if err != nil {
+			return err
+		}
+		elem := reflect.New(inst.Type().Elem()).Elem()
+		if it := inst.MapIndex(key); it.IsValid() {
+			elem.Set(it)
+		}
+		if err := w.modify(elem, segs[1:], fn); err != nil {
+			return err
+		}
+		if inst.IsNil() {
+			if !inst.CanSet() {
+				return fmt.Errorf("nil %s cannot be set", inst.Type())
+			}
+			inst.Set(reflect.MakeMap(inst.Type()))
+		}
+		inst.SetMapIndex(key, elem)
+		return nil
+	case reflect.Slice, reflect.Array:
+		if seg == "-" && inst.Kind() == reflect.Slice {
+			if !inst.CanSet() {
+				return fmt.Errorf("%s cannot be set", inst.Type())
+			}
+			inst.Set(reflect.Append(inst, reflect.Zero(inst.Type().Elem())))
+			return w.modify(inst.Index(inst.Len()-1), segs[1:], fn)
+		}
+		length := inst.Len()
+		if w.grow > length && inst.Kind() == reflect.Slice && inst.CanSet() {
+			length = w.grow
+		}
+		idx, err := pathIndex(seg, length)
+		if err != nil {
+			return err
+		}
+		if idx >= inst.Len() {
+			sl := reflect.MakeSlice(inst.Type(), idx+1, idx+1)
+			reflect.Copy(sl, inst)
+			inst.Set(sl)
+		}
+		return w.modify(inst.Index(idx), segs[1:], fn)
+	}
+	return fmt.Errorf("cannot index %s with %q", inst.Type(), seg)
+}
+
+// add 按 JSON Patch add 的语义写入：父路径必须存在，slice插入到下标处(- 表示末尾)，map新增或覆盖key
+func (w pathWalker) add(inst reflect.Value, segs []string, value interface{}, fieldName string) error {
+	if len(segs) == 0 {
+		return assignValue(inst, value, fieldName, w.optArgs)
+	}
+	last := len(segs) - 1
+	if _, err := w.get(inst, segs[:last]); err != nil {
+		return err
+	}
+	return w.modify(inst, segs[:last], func(parent reflect.Value) error {
+		return w.insert(parent, segs[last], value, fieldName)
+	})
+}
+
+// insert 把value插入inst中seg的位置，slice之外的容器直接设置
+func (w pathWalker) insert(inst reflect.Value, seg string, value interface{}, fieldName string) error {
+	switch inst.Kind() {
+	case reflect.Ptr:
+		if inst.IsNil() {
+			return fmt.Errorf("nil %s", inst.Type())
+		}
+		return w.insert(inst.Elem(), seg, value, fieldName)
+	case reflect.Interface:
+		if inst.IsNil() || !inst.CanSet() {
+			return fmt.Errorf("%s cannot be set", inst.Type())
+		}
+		elem := reflect.New(inst.Elem().Type()).Elem()
+		elem.Set(inst.Elem())
+		if err := w.insert(elem, seg, value, fieldName); err != nil {
+			return err
+		}
+		inst.Set(elem)
+		return nil
+	case reflect.Slice:
+		idx := inst.Len()
+		if seg != "-" {
+			var err error
+			if idx, err = pathIndex(seg, inst.Len()+1); err != nil {
+				return err
+			}
+		}
+		if !inst.CanSet() {
+			return fmt.Errorf("%s cannot be set", inst.Type())
+		}
+		elem := reflect.New(inst.Type().Elem()).Elem()
+		if err := assignValue(elem, value, fieldName, w.optArgs); err != nil {
+			return err
+		}
+		out := reflect.MakeSlice(inst.Type(), 0, inst.Len()+1)
+		out = reflect.AppendSlice(out, inst.Slice(0, idx))
+		out = reflect.Append(out, elem)
+		out = reflect.AppendSlice(out, inst.Slice(idx, inst.Len()))
+		inst.Set(out)
+		return nil
+	}
+	return w.modify(inst, []string{seg}, func(field reflect.Value) error {
+		return assignValue(field, value, fieldName, w.optArgs)
+	})
+}
+
+// removePath 删除路径对应的值，路径必须存在
+func (w pathWalker) removePath(inst reflect.Value, segs []string) error {
+	if len(segs) == 0 {
+		return fmt.Errorf("cannot remove the whole document")
+	}
+	// 先确认路径存在，避免分配中间的nil指针/map
+	if _, err := w.get(inst, segs); err != nil {
+		return err
+	}
+	last := len(segs) - 1
+	return w.modify(inst, segs[:last], func(parent reflect.Value) error {
+		return w.remove(parent, segs[last])
+	})
+}
+
+// remove 删除inst中seg对应的元素：map删除key，slice删除元素，结构体字段置为0值
+func (w pathWalker) remove(inst reflect.Value, seg string) error {
+	switch inst.Kind() {
+	case reflect.Ptr:
+		if inst.IsNil() {
+			return fmt.Errorf("nil %s", inst.Type())
+		}
+		return w.remove(inst.Elem(), seg)
+	case reflect.Interface:
+		if inst.IsNil() || !inst.CanSet() {
+			return fmt.Errorf("%s cannot be set", inst.Type())
+		}
+		elem := reflect.New(inst.Elem().Type()).Elem()
+		elem.Set(inst.Elem())
+		if err := w.remove(elem, seg); err != nil {
+			return err
+		}
+		inst.Set(elem)
+		return nil
+	case reflect.Struct:
+		field, ok := w.field(inst, seg, false)
+		if !ok {
+			return fmt.Errorf("field %q %w in %s", seg, errPathNotFound, inst.Type())
+		}
+		if !field.CanSet() {
+			return fmt.Errorf("field %q cannot be set", seg)
+		}
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	case reflect.Map:
+		key, err := pathMapKey(inst.Type().Key(), seg, w.optArgs)
+		if err != nil {
+			return err
+		}
+		if !inst.MapIndex(key).IsValid() {
+			return fmt.Errorf("key %q %w in %s", seg, errPathNotFound, inst.Type())
+		}
+		inst.SetMapIndex(key, reflect.Value{})
+		return nil
+	case reflect.Slice:
+		idx, err := pathIndex(seg, inst.Len())
+		if err != nil {
+			return err
+		}
+		if !inst.CanSet() {
+			return fmt.Errorf("%s cannot be set", inst.Type())
+		}
+		// 重新分配，不修改原来的底层数组
+		out := reflect.MakeSlice(inst.Type(), 0, inst.Len()-1)
+		out = reflect.AppendSlice(out, inst.Slice(0, idx))
+		out = reflect.AppendSlice(out, inst.Slice(idx+1, inst.Len()))
+		inst.Set(out)
+		return nil
+	}
+	return fmt.Errorf("cannot remove %q from %s", seg, inst.Type())
+}
+
+// field 按字段名，json/gorm/xorm tag 或小驼峰字段名查找字段
+// 同时查找匿名组合提升的字段，alloc为true时分配匿名组合的nil指针
+func (w pathWalker) field(inst reflect.Value, name string, alloc bool) (reflect.Value, bool) {
+	fieldType, index, ok := w.fieldIndex(inst.Type(), name)
+	if !ok {
+		return reflect.Value{}, false
+	}
+	var field reflect.Value
+	if alloc {
+		field = streamFieldByIndex(inst, index)
+	} else {
+		field = fieldByIndex(inst, index)
+	}
+	if !field.IsValid() {
+		return reflect.Value{}, false
+	}
+	return accessibleField(field, fieldType, w.optArgs)
+}
+
+// fieldIndex 依次按字段名，当前层的tag名，匿名组合提升的tag名查找
+// tagOnly时只按 structFields 展开后的字段名查找
+func (w pathWalker) fieldIndex(tpe reflect.Type, name string) (reflect.StructField, []int, bool) {
+	if !w.tagOnly {
+		if fieldType, ok := tpe.FieldByName(name); ok {
+			return fieldType, fieldType.Index, true
+		}
+		for i := 0; i < tpe.NumField(); i++ {
+			fieldType := tpe.Field(i)
+			if fieldName, _, _ := getFieldTag(fieldType, w.optArgs); fieldName == name {
+				return fieldType, fieldType.Index, true
+			}
+		}
+	}
+	for _, sf := range structFields(tpe, w.optArgs) {
+		if sf.name == name {
+			return sf.fieldType, sf.index, true
+		}
+	}
+	return reflect.StructField{}, nil, false
+}
+
+// pathMapKey 把路径中的段转换为map的key，数字和bool类型的key必须能解析
+func pathMapKey(keyType reflect.Type, seg string, optArgs *args) (reflect.Value, error) {
+	key := reflect.New(keyType).Elem()
+	var err error
+	switch keyType.Kind() {
+	case reflect.String:
+		key.SetString(seg)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		if n, err = strconv.ParseInt(seg, 10, keyType.Bits()); err == nil {
+			key.SetInt(n)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n uint64
+		if n, err = strconv.ParseUint(seg, 10, keyType.Bits()); err == nil {
+			key.SetUint(n)
+		}
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if f, err = strconv.ParseFloat(seg, keyType.Bits()); err == nil {
+			key.SetFloat(f)
+		}
+	case reflect.Bool:
+		var b bool
+		if b, err = strconv.ParseBool(seg); err == nil {
+			key.SetBool(b)
+		}
+	default:
+		err = valueDeepCopy(key, seg, 0, seg, optArgs)
+	}
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("bad map key %q: %v", seg, err)
+	}
+	return key, nil
+}
+
+// pathIndex 把路径中的段转换为slice/array的下标，只接受不带前导0的十进制数
+func pathIndex(seg string, length int) (int, error) {
+	if seg == "" || strings.Trim(seg, "0123456789") != "" || (len(seg) > 1 && seg[0] == '0') {
+		return 0, fmt.Errorf("bad index %q", seg)
+	}
+	idx, err := strconv.Atoi(seg)
+	if err != nil {
+		return 0, fmt.Errorf("bad index %q", seg)
+	}
+	if idx >= length {
+		return 0, fmt.Errorf("index %d out of range [0:%d]", idx, length)
+	}
+	return idx, nil
+}
+
+// assignValue 把value写入dest
+// 类型一致(或只差一层指针)时深度拷贝，否则按 InstanceFromMap 的规则转换
+// 不同类型的结构体按tag字段名转换
+func assignValue(dest reflect.Value, value interface{}, fieldName string, optArgs *args) error {
+	if !dest.CanSet() {
+		return fmt.Errorf("%s cannot be set", fieldName)
+	}
+	if value == nil {
+		dest.Set(reflect.Zero(dest.Type()))
+		return nil
+	}
+	from := reflect.ValueOf(value)
+	if from.Kind() == reflect.Ptr && from.Type().Elem() == dest.Type() && !from.IsNil() {
+		from = from.Elem()
+	}
+	if from.Type() == dest.Type() {
+		cloneValue(dest, addressableValue(from), newCloneState(), optArgs)
+		return nil
+	}
+	if dest.Kind() == reflect.Ptr && from.Type() == dest.Type().Elem() {
+		it := reflect.New(from.Type())
+		cloneValue(it.Elem(), addressableValue(from), newCloneState(), optArgs)
+		dest.Set(it)
+		return nil
+	}
+	if dest.Kind() == reflect.Interface && from.Type().AssignableTo(dest.Type()) {
+		it := reflect.New(from.Type()).Elem()
+		cloneValue(it, addressableValue(from), newCloneState(), optArgs)
+		dest.Set(it)
+		return nil
+	}
+	switch from.Kind() {
+	case reflect.Ptr, reflect.Struct, reflect.Map, reflect.Slice:
+		// 不同类型的结构体/容器先转换成 InstanceToMap 输出的通用数据
+		generic, err := toGenericValue(from, optArgs)
+		if err != nil {
+			return err
+		}
+		value = generic
+	}
+	return valueDeepCopy(dest, value, 0, fieldName, optArgs)
+}
@@ -0,0 +1,187 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: path_test.go
+ * @time: 2026/10/19 21:40
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"reflect"
+	"testing"
+)
+
+type pathAddress struct {
+	City string `json:"city"`
+	Zip  int    `json:"zip"`
+}
+
+type pathProfile struct {
+	Addresses []pathAddress `json:"addresses"`
+	Home      *pathAddress  `json:"home"`
+}
+
+type pathUser struct {
+	Name    string                 `json:"name"`
+	Profile *pathProfile           `json:"profile"`
+	Tags    map[string]string      `json:"tags"`
+	Scores  map[int]float64        `json:"scores"`
+	Extra   map[string]interface{} `json:"extra"`
+}
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		want    []string
+		wantErr bool
+	}{
+		{name: "TestParsePath_field", path: "name", want: []string{"name"}},
+		{name: "TestParsePath_nested", path: "profile.addresses[0].city", want: []string{"profile", "addresses", "0", "city"}},
+		{name: "TestParsePath_quoted", path: `tags["a.b"]`, want: []string{"tags", "a.b"}},
+		{name: "TestParsePath_single", path: `tags['it\'s'][1]`, want: []string{"tags", "it's", "1"}},
+		{name: "TestParsePath_bare", path: "tags[env].x", want: []string{"tags", "env", "x"}},
+		{name: "TestParsePath_empty", path: "", wantErr: true},
+		{name: "TestParsePath_emptySeg", path: "a..b", wantErr: true},
+		{name: "TestParsePath_unclosed", path: `tags["env`, wantErr: true},
+		{name: "TestParsePath_trailing", path: "tags[0]x", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePath(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsePath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetFieldValuePath(t *testing.T) {
+	obj := pathUser{
+		Name: "zgd",
+		Profile: &pathProfile{
+			Addresses: []pathAddress{{City: "xm", Zip: 361000}},
+		},
+		Tags:   map[string]string{"env": "prod"},
+		Scores: map[int]float64{7: 9.5},
+		Extra:  map[string]interface{}{"list": []interface{}{"a", "b"}},
+	}
+	tests := []struct {
+		name string
+		path string
+		want interface{}
+	}{
+		{name: "TestGetFieldValuePath_tag", path: "profile.addresses[0].city", want: "xm"},
+		{name: "TestGetFieldValuePath_goName", path: "Profile.Addresses[0].Zip", want: 361000},
+		{name: "TestGetFieldValuePath_mapKey", path: `tags["env"]`, want: "prod"},
+		{name: "TestGetFieldValuePath_intKey", path: "scores[7]", want: 9.5},
+		{name: "TestGetFieldValuePath_interface", path: "extra.list[1]", want: "b"},
+		{name: "TestGetFieldValuePath_outOfRange", path: "profile.addresses[3].city", want: nil},
+		{name: "TestGetFieldValuePath_nilPtr", path: "profile.home.city", want: nil},
+		{name: "TestGetFieldValuePath_missingKey", path: `tags["none"]`, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetFieldValue(&obj, tt.path); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetFieldValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if obj.Profile.Home != nil {
+		t.Errorf("GetFieldValue() should not allocate nil pointer")
+	}
+}
+
+func TestSetFieldValuePath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		value   interface{}
+		check   func(obj *pathUser) interface{}
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name:  "TestSetFieldValuePath_allocPtr",
+			path:  "profile.home.city",
+			value: "sh",
+			check: func(obj *pathUser) interface{} { return obj.Profile.Home.City },
+			want:  "sh",
+		},
+		{
+			name:  "TestSetFieldValuePath_slice",
+			path:  "profile.addresses[0].zip",
+			value: "200000",
+			check: func(obj *pathUser) interface{} { return obj.Profile.Addresses[0].Zip },
+			want:  200000,
+		},
+		{
+			name:  "TestSetFieldValuePath_allocMap",
+			path:  `tags["env"]`,
+			value: "dev",
+			check: func(obj *pathUser) interface{} { return obj.Tags["env"] },
+			want:  "dev",
+		},
+		{
+			name:  "TestSetFieldValuePath_intKey",
+			path:  "scores[3]",
+			value: 1,
+			check: func(obj *pathUser) interface{} { return obj.Scores[3] },
+			want:  1.0,
+		},
+		{
+			name:  "TestSetFieldValuePath_interface",
+			path:  "extra.meta.owner",
+			value: "ops",
+			check: func(obj *pathUser) interface{} { return obj.Extra["meta"].(map[string]interface{})["owner"] },
+			want:  "ops",
+		},
+		{
+			name:  "TestSetFieldValuePath_struct",
+			path:  "profile.home",
+			value: pathAddress{City: "bj"},
+			check: func(obj *pathUser) interface{} { return obj.Profile.Home.City },
+			want:  "bj",
+		},
+		{
+			name:    "TestSetFieldValuePath_outOfRange",
+			path:    "profile.addresses[5].city",
+			value:   "x",
+			wantErr: true,
+		},
+		{
+			name:    "TestSetFieldValuePath_notFound",
+			path:    "profile.none",
+			value:   "x",
+			wantErr: true,
+		},
+		{
+			name:  "TestSetFieldValuePath_topNotFound",
+			path:  "none",
+			value: "x",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &pathUser{Profile: &pathProfile{Addresses: []pathAddress{{City: "xm"}}}}
+			err := SetFieldValue(obj, tt.path, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetFieldValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.check != nil {
+				if got := tt.check(obj); !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("SetFieldValue() got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: pointer.go
+ * @time: 2026/10/19 22:20
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// GetByPointer 按 JSON Pointer(RFC 6901) 读取值，如 /orders/3/items/sku
+// 结构体字段按 WithFieldType 选择的tag字段名匹配，空字符串表示整个对象
+func GetByPointer(target interface{}, pointer string, opts ...CopyOption) (interface{}, error) {
+	if target == nil {
+		return nil, errors.New("nil target")
+	}
+	segs, err := parsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	optArgs := newOpts(opts...)
+
+	field, err := pathWalker{optArgs: &optArgs, tagOnly: true}.get(reflect.ValueOf(target), segs)
+	if err != nil {
+		return nil, err
+	}
+	if !field.CanInterface() {
+		return nil, fmt.Errorf("pointer %s cannot be accessed", pointer)
+	}
+	return field.Interface(), nil
+}
+
+// SetByPointer 按 JSON Pointer 设置值，ptr必须为指针
+// 中间的nil指针和map会自动分配，map的key不存在时新增，slice的下标为 - 时追加
+// 值按 InstanceFromMap 的规则转换
+func SetByPointer(ptr interface{}, pointer string, value interface{}, opts ...CopyOption) (err error) {
+	inst, segs, err := pointerTarget(ptr, pointer)
+	if err != nil {
+		return err
+	}
+	optArgs := newOpts(opts...)
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("set by pointer err=[%v]", r)
+		}
+	}()
+
+	return pathWalker{optArgs: &optArgs, tagOnly: true}.modify(inst, segs, func(field reflect.Value) error {
+		return assignValue(field, value, pointer, &optArgs)
+	})
+}
+
+// DeleteByPointer 按 JSON Pointer 删除值，ptr必须为指针
+// map删除对应的key，slice删除对应的元素，结构体字段置为0值；路径不存在时返回错误
+func DeleteByPointer(ptr interface{}, pointer string, opts ...CopyOption) (err error) {
+	inst, segs, err := pointerTarget(ptr, pointer)
+	if err != nil {
+		return err
+	}
+	optArgs := newOpts(opts...)
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("delete by pointer err=[%v]", r)
+		}
+	}()
+
+	return pathWalker{optArgs: &optArgs, tagOnly: true}.removePath(inst, segs)
+}
+
+// pointerTarget 检查ptr并解析pointer
+func pointerTarget(ptr interface{}, pointer string) (reflect.Value, []string, error) {
+	inst := reflect.ValueOf(ptr)
+	if inst.Kind() != reflect.Ptr || inst.IsNil() {
+		return reflect.Value{}, nil, errors.New("not pointer target")
+	}
+	segs, err := parsePointer(pointer)
+	if err != nil {
+		return reflect.Value{}, nil, err
+	}
+	return inst.Elem(), segs, nil
+}
+
+// parsePointer 解析 JSON Pointer，~1 还原为 /，~0 还原为 ~
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("pointer %q must start with /", pointer)
+	}
+	segs := strings.Split(pointer[1:], "/")
+	for i, seg := range segs {
+		for j := 0; j < len(seg); j++ {
+			if seg[j] == '~' && (j+1 >= len(seg) || (seg[j+1] != '0' && seg[j+1] != '1')) {
+				return nil, fmt.Errorf("pointer %q: bad escape in %q", pointer, seg)
+			}
+		}
+		segs[i] = strings.ReplaceAll(strings.ReplaceAll(seg, "~1", "/"), "~0", "~")
+	}
+	return segs, nil
+}
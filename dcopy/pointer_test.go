@@ -0,0 +1,242 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: pointer_test.go
+ * @time: 2026/10/19 22:20
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"reflect"
+	"testing"
+)
+
+type pointerItem struct {
+	Sku string `json:"sku" gorm:"column:item_sku"`
+	Qty int    `json:"qty"`
+}
+
+type pointerOrder struct {
+	ID    int64          `json:"id"`
+	Items []*pointerItem `json:"items"`
+}
+
+type pointerDoc struct {
+	Orders []pointerOrder         `json:"orders"`
+	Attrs  map[string]string      `json:"attrs"`
+	Meta   map[string]interface{} `json:"meta"`
+}
+
+func newPointerDoc() *pointerDoc {
+	return &pointerDoc{
+		Orders: []pointerOrder{
+			{ID: 1, Items: []*pointerItem{{Sku: "a", Qty: 1}, {Sku: "b", Qty: 2}}},
+		},
+		Attrs: map[string]string{"a/b": "slash", "m~n": "tilde"},
+		Meta:  map[string]interface{}{"tags": []interface{}{"x", "y"}},
+	}
+}
+
+func TestParsePointer(t *testing.T) {
+	tests := []struct {
+		name    string
+		pointer string
+		want    []string
+		wantErr bool
+	}{
+		{name: "TestParsePointer_root", pointer: "", want: nil},
+		{name: "TestParsePointer_nested", pointer: "/orders/0/items", want: []string{"orders", "0", "items"}},
+		{name: "TestParsePointer_escape", pointer: "/a~1b/m~0n/~01", want: []string{"a/b", "m~n", "~1"}},
+		{name: "TestParsePointer_emptyKey", pointer: "/", want: []string{""}},
+		{name: "TestParsePointer_noSlash", pointer: "orders", wantErr: true},
+		{name: "TestParsePointer_badEscape", pointer: "/a~2", wantErr: true},
+		{name: "TestParsePointer_tailEscape", pointer: "/a~", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePointer(tt.pointer)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePointer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsePointer() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetByPointer(t *testing.T) {
+	doc := newPointerDoc()
+	tests := []struct {
+		name    string
+		pointer string
+		opts    []CopyOption
+		want    interface{}
+		wantErr bool
+	}{
+		{name: "TestGetByPointer_nested", pointer: "/orders/0/items/1/sku", want: "b"},
+		{name: "TestGetByPointer_escape", pointer: "/attrs/a~1b", want: "slash"},
+		{name: "TestGetByPointer_tilde", pointer: "/attrs/m~0n", want: "tilde"},
+		{name: "TestGetByPointer_interface", pointer: "/meta/tags/0", want: "x"},
+		{name: "TestGetByPointer_gorm", pointer: "/orders/0/items/0/item_sku", opts: []CopyOption{WithFieldType(FieldType_Gorm)}, want: "a"},
+		{name: "TestGetByPointer_goName", pointer: "/Orders/0", wantErr: true},
+		{name: "TestGetByPointer_leadingZero", pointer: "/orders/00", wantErr: true},
+		{name: "TestGetByPointer_append", pointer: "/orders/-", wantErr: true},
+		{name: "TestGetByPointer_outOfRange", pointer: "/orders/0/items/2", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetByPointer(doc, tt.pointer, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetByPointer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetByPointer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got, err := GetByPointer(doc, ""); err != nil || got != doc {
+		t.Errorf("GetByPointer() root = %v, %v", got, err)
+	}
+}
+
+func TestSetByPointer(t *testing.T) {
+	tests := []struct {
+		name    string
+		pointer string
+		value   interface{}
+		check   func(doc *pointerDoc) interface{}
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name:    "TestSetByPointer_coerce",
+			pointer: "/orders/0/items/0/qty",
+			value:   "5",
+			check:   func(doc *pointerDoc) interface{} { return doc.Orders[0].Items[0].Qty },
+			want:    5,
+		},
+		{
+			name:    "TestSetByPointer_append",
+			pointer: "/orders/0/items/-",
+			value:   map[string]interface{}{"sku": "c", "qty": 3},
+			check:   func(doc *pointerDoc) interface{} { return *doc.Orders[0].Items[2] },
+			want:    pointerItem{Sku: "c", Qty: 3},
+		},
+		{
+			name:    "TestSetByPointer_appendField",
+			pointer: "/orders/-/id",
+			value:   2,
+			check:   func(doc *pointerDoc) interface{} { return doc.Orders[1].ID },
+			want:    int64(2),
+		},
+		{
+			name:    "TestSetByPointer_newKey",
+			pointer: "/attrs/c~1d",
+			value:   "new",
+			check:   func(doc *pointerDoc) interface{} { return doc.Attrs["c/d"] },
+			want:    "new",
+		},
+		{
+			name:    "TestSetByPointer_interfaceSlice",
+			pointer: "/meta/tags/1",
+			value:   "z",
+			check:   func(doc *pointerDoc) interface{} { return doc.Meta["tags"] },
+			want:    []interface{}{"x", "z"},
+		},
+		{
+			name:    "TestSetByPointer_outOfRange",
+			pointer: "/orders/3/id",
+			value:   1,
+			wantErr: true,
+		},
+		{
+			name:    "TestSetByPointer_notFound",
+			pointer: "/none",
+			value:   1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := newPointerDoc()
+			err := SetByPointer(doc, tt.pointer, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetByPointer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.check != nil {
+				if got := tt.check(doc); !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("SetByPointer() got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+	if err := SetByPointer(pointerDoc{}, "/attrs/a", 1); err == nil {
+		t.Errorf("SetByPointer() want error for non-pointer target")
+	}
+}
+
+func TestDeleteByPointer(t *testing.T) {
+	tests := []struct {
+		name    string
+		pointer string
+		check   func(doc *pointerDoc) interface{}
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name:    "TestDeleteByPointer_slice",
+			pointer: "/orders/0/items/0",
+			check:   func(doc *pointerDoc) interface{} { return len(doc.Orders[0].Items) },
+			want:    1,
+		},
+		{
+			name:    "TestDeleteByPointer_map",
+			pointer: "/attrs/a~1b",
+			check:   func(doc *pointerDoc) interface{} { return len(doc.Attrs) },
+			want:    1,
+		},
+		{
+			name:    "TestDeleteByPointer_interfaceSlice",
+			pointer: "/meta/tags/0",
+			check:   func(doc *pointerDoc) interface{} { return doc.Meta["tags"] },
+			want:    []interface{}{"y"},
+		},
+		{
+			name:    "TestDeleteByPointer_field",
+			pointer: "/orders/0/id",
+			check:   func(doc *pointerDoc) interface{} { return doc.Orders[0].ID },
+			want:    int64(0),
+		},
+		{
+			name:    "TestDeleteByPointer_missingKey",
+			pointer: "/attrs/none",
+			wantErr: true,
+		},
+		{
+			name:    "TestDeleteByPointer_root",
+			pointer: "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := newPointerDoc()
+			err := DeleteByPointer(doc, tt.pointer)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DeleteByPointer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.check != nil {
+				if got := tt.check(doc); !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("DeleteByPointer() got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,345 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: stream.go
+ * @time: 2026/10/19 16:30
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// InstanceFromReader 从io.Reader流式解析json到dest(必须为指针)
+// 不需要先解析到map[string]interface{}，边读取token边完成类型转换，规则同 InstanceFromMap
+// 数字按 UseNumber 处理，int64/uint64 不会经过float64丢失精度
+// reader中只能包含一个json值，之后还有其他数据时返回错误
+func InstanceFromReader(dest interface{}, reader io.Reader, opts ...CopyOption) (err error) {
+	optArgs := newOpts(opts...)
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New(interface2String(r))
+			printLog(&optArgs, 0, r)
+		}
+	}()
+
+	inst := reflect.ValueOf(dest)
+	if inst.Kind() != reflect.Ptr || inst.IsNil() {
+		return errors.New("not ptr type")
+	}
+	dec := json.NewDecoder(reader)
+	dec.UseNumber()
+	if _, err = streamDecode(dec, inst.Elem(), 0, "", &optArgs); err != nil {
+		return
+	}
+	if _, err = dec.Token(); err != io.EOF {
+		return fmt.Errorf("invalid data after top-level value, offset=%d", dec.InputOffset())
+	}
+	return nil
+}
+
+// streamDecode 读取下一个json值写入inst
+// 返回值为false表示读到的是null，inst未修改
+func streamDecode(dec *json.Decoder, inst reflect.Value, deep int, fieldName string, optArgs *args) (bool, error) {
+	if isStreamLeaf(inst.Type()) {
+		// interface/time.Time等整体读取后按 valueDeepCopy 规则转换
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			return false, err
+		}
+		if v == nil {
+			return false, nil
+		}
+		if inst.Kind() != reflect.Interface && !isBigType(inst.Type()) {
+			v = streamLeafValue(v)
+		}
+		return true, valueDeepCopy(inst, v, deep, fieldName, optArgs)
+	}
+
+	if inst.Kind() == reflect.Ptr {
+		if optArgs.mergeStrategy != MergeStrategy_Replace && !inst.IsNil() {
+			// 复用已有的对象
+			return streamDecode(dec, inst.Elem(), deep+1, fieldName, optArgs)
+		}
+		it := reflect.New(inst.Type().Elem())
+		ok, err := streamDecode(dec, it.Elem(), deep+1, fieldName, optArgs)
+		if err != nil || !ok {
+			return ok, err
+		}
+		printLog(optArgs, deep, "Ptr>>:", it.String())
+		inst.Set(it)
+		return true, nil
+	}
+
+	tok, err := dec.Token()
+	if err != nil {
+		return false, err
+	}
+	switch tok {
+	case nil:
+		return false, nil
+	case json.Delim('{'):
+		switch inst.Kind() {
+		case reflect.Struct:
+			return true, streamStruct(dec, inst, deep, optArgs)
+		case reflect.Map:
+			return true, streamMap(dec, inst, deep, fieldName, optArgs)
+		}
+		return true, streamSkip(dec, 1)
+	case json.Delim('['):
+		switch inst.Kind() {
+		case reflect.Slice:
+			return true, streamSlice(dec, inst, deep, fieldName, optArgs)
+		case reflect.Array:
+			return true, streamArray(dec, inst, deep, fieldName, optArgs)
+		}
+		return true, streamSkip(dec, 1)
+	}
+	return true, valueDeepCopy(inst, streamLeafValue(tok), deep, fieldName, optArgs)
+}
+
+func streamStruct(dec *json.Decoder, inst reflect.Value, deep int, optArgs *args) error {
+	printLog(optArgs, deep, "Struct>>:", inst.String())
+	fields, inline := streamFieldIndex(inst.Type(), optArgs)
+	// 有inline map字段时同 valueDeepCopy，当前层的全部数据同时写入inline map
+	var rest map[string]interface{}
+	if len(inline) > 0 {
+		rest = make(map[string]interface{})
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := tok.(string)
+		index, ok := fields[key]
+		if rest == nil {
+			if !ok {
+				if err := streamSkipValue(dec); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := streamField(dec, inst, index, deep, key, optArgs); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return err
+		}
+		var v interface{}
+		if err := newStreamDecoder(raw).Decode(&v); err != nil {
+			return err
+		}
+		rest[key] = streamLeafValue(v)
+		if ok {
+			if err := streamField(newStreamDecoder(raw), inst, index, deep, key, optArgs); err != nil {
+				return err
+			}
+		}
+	}
+	for name, index := range inline {
+		field, _ := exposeValue(streamFieldByIndex(inst, index))
+		if !field.IsValid() || !field.CanSet() {
+			continue
+		}
+		if err := valueDeepCopy(field, rest, deep+1, name, optArgs); err != nil {
+			return err
+		}
+	}
+	_, err := dec.Token() // '}'
+	return err
+}
+
+// streamField 读取下一个json值写入inst中index对应的字段，字段不可设置时跳过
+func streamField(dec *json.Decoder, inst reflect.Value, index []int, deep int, key string, optArgs *args) error {
+	field, _ := exposeValue(streamFieldByIndex(inst, index))
+	if !field.IsValid() || !field.CanSet() {
+		return streamSkipValue(dec)
+	}
+	_, err := streamDecode(dec, field, deep+1, key, optArgs)
+	return err
+}
+
+func newStreamDecoder(raw []byte) *json.Decoder {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	return dec
+}
+
+func streamMap(dec *json.Decoder, inst reflect.Value, deep int, fieldName string, optArgs *args) error {
+	tpe := inst.Type()
+	mp := reflect.MakeMap(tpe)
+	if optArgs.mergeStrategy != MergeStrategy_Replace && !inst.IsNil() {
+		mp = inst
+	}
+	printLog(optArgs, deep, "Map>>:", mp.String())
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key := reflect.New(tpe.Key()).Elem()
+		if err := valueDeepCopy(key, tok, deep+1, fieldName, optArgs); err != nil {
+			return err
+		}
+		val := reflect.New(tpe.Elem()).Elem()
+		if optArgs.mergeStrategy != MergeStrategy_Replace {
+			if existing := mp.MapIndex(key); existing.IsValid() {
+				val.Set(existing)
+			}
+		}
+		if _, err := streamDecode(dec, val, deep+1, fieldName, optArgs); err != nil {
+			return err
+		}
+		mp.SetMapIndex(key, val)
+	}
+	inst.Set(mp)
+	_, err := dec.Token() // '}'
+	return err
+}
+
+func streamSlice(dec *json.Decoder, inst reflect.Value, deep int, fieldName string, optArgs *args) error {
+	tpe := inst.Type()
+	sl := reflect.MakeSlice(tpe, 0, 0)
+	if optArgs.mergeStrategy == MergeStrategy_AppendSlices && inst.Len() > 0 {
+		sl = reflect.MakeSlice(tpe, inst.Len(), inst.Len())
+		reflect.Copy(sl, inst)
+	}
+	printLog(optArgs, deep, "Slice>>:", sl.String())
+	for dec.More() {
+		val := reflect.New(tpe.Elem()).Elem()
+		if _, err := streamDecode(dec, val, deep+1, fieldName, optArgs); err != nil {
+			return err
+		}
+		sl = reflect.Append(sl, val)
+	}
+	inst.Set(sl)
+	_, err := dec.Token() // ']'
+	return err
+}
+
+func streamArray(dec *json.Decoder, inst reflect.Value, deep int, fieldName string, optArgs *args) error {
+	for i := 0; dec.More(); i++ {
+		if i >= inst.Len() {
+			if err := streamSkipValue(dec); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := streamDecode(dec, inst.Index(i), deep+1, fieldName, optArgs); err != nil {
+			return err
+		}
+	}
+	_, err := dec.Token() // ']'
+	return err
+}
+
+// streamSkipValue 跳过下一个json值
+func streamSkipValue(dec *json.Decoder) error {
+	var raw json.RawMessage
+	return dec.Decode(&raw)
+}
+
+// streamSkip 跳过已读取了起始分隔符的对象/数组
+func streamSkip(dec *json.Decoder, depth int) error {
+	for depth > 0 {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth += 1
+		case json.Delim('}'), json.Delim(']'):
+			depth -= 1
+		}
+	}
+	return nil
+}
+
+// isStreamLeaf 不按token逐层解析的类型
+func isStreamLeaf(tpe reflect.Type) bool {
+	switch tpe.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Struct:
+		return tpe == reflect.TypeOf(time.Time{}) || isBigType(tpe)
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return true
+	}
+	return false
+}
+
+// streamLeafValue json.Number 转成不丢失精度的 int64/uint64/float64
+func streamLeafValue(v interface{}) interface{} {
+	n, ok := v.(json.Number)
+	if !ok {
+		return v
+	}
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+	if u, err := strconv.ParseUint(n.String(), 10, 64); err == nil {
+		return u
+	}
+	if f, err := n.Float64(); err == nil {
+		return f
+	}
+	return n.String()
+}
+
+// streamFieldIndex 按 structFields 的规则建立 字段名 -> 字段索引 的映射
+// inline map字段单独返回，不按字段名匹配
+func streamFieldIndex(tpe reflect.Type, optArgs *args) (map[string][]int, map[string][]int) {
+	fields := structFields(tpe, optArgs)
+	out := make(map[string][]int, len(fields))
+	var inline map[string][]int
+	for _, sf := range fields {
+		if sf.inlineMap {
+			if inline == nil {
+				inline = make(map[string][]int)
+			}
+			inline[sf.name] = sf.index
+			continue
+		}
+		out[sf.name] = sf.index
+	}
+	return out, inline
+}
+
+// streamFieldByIndex 同 FieldByIndex，途经的nil指针会自动分配
+func streamFieldByIndex(inst reflect.Value, index []int) reflect.Value {
+	for i, idx := range index {
+		if i > 0 && inst.Kind() == reflect.Ptr {
+			if inst.IsNil() {
+				if !inst.CanSet() {
+					return reflect.Value{}
+				}
+				inst.Set(reflect.New(inst.Type().Elem()))
+			}
+			inst = inst.Elem()
+		}
+		if inst.Kind() != reflect.Struct {
+			panic(fmt.Sprintf("field index %v on %s", index, inst.Type()))
+		}
+		inst = inst.Field(idx)
+	}
+	return inst
+}
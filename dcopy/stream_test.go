@@ -0,0 +1,144 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: stream_test.go
+ * @time: 2026/10/19 17:05
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type streamFoo struct {
+	*InnerFoo
+	ID      int64                  `json:"id"`
+	UID     uint64                 `json:"uid"`
+	Name    string                 `json:"name"`
+	Score   float64                `json:"score"`
+	Ok      bool                   `json:"ok"`
+	Ptr     *int                   `json:"ptr"`
+	Nil     *int                   `json:"nil"`
+	Time    time.Time              `json:"time"`
+	Any     interface{}            `json:"any"`
+	Inner   InnerStruct            `json:"inner"`
+	Arr     []InnerStruct          `json:"arr"`
+	Fixed   [2]int                 `json:"fixed"`
+	MapInt  map[string]int         `json:"map_int"`
+	MapKey  map[int]string         `json:"map_key"`
+	MapAny  map[string]interface{} `json:"map_any"`
+	Skipped int                    `json:"-"`
+}
+
+func TestInstanceFromReader(t *testing.T) {
+	data := `{
+		"tt": 7,
+		"id": 9007199254740993,
+		"uid": "18446744073709551615",
+		"name": 123,
+		"score": "1.5",
+		"ok": "true",
+		"ptr": "12",
+		"nil": null,
+		"time": "2020-10-16 00:00:00",
+		"any": 9007199254740993,
+		"unknown": {"a": [1, 2, {"b": 3}]},
+		"inner": {"aa": "11", "bb": 22},
+		"arr": [{"aa": 1}, {"aa": "2"}],
+		"fixed": ["1", 2, 3],
+		"map_int": {"a": "1", "b": 2.0},
+		"map_key": {"1": "a"},
+		"map_any": {"a": [1]},
+		"Skipped": 1
+	}`
+	got := &streamFoo{}
+	if err := InstanceFromReader(got, strings.NewReader(data)); err != nil {
+		t.Fatalf("InstanceFromReader() error = %v", err)
+	}
+
+	ptr := 12
+	want := &streamFoo{
+		InnerFoo: &InnerFoo{TT: "7"},
+		ID:       9007199254740993,
+		UID:      18446744073709551615,
+		Name:     "123",
+		Score:    1.5,
+		Ok:       true,
+		Ptr:      &ptr,
+		Time:     time.Date(2020, 10, 16, 0, 0, 0, 0, time.Local),
+		Any:      json.Number("9007199254740993"),
+		Inner:    InnerStruct{A: 11, B: "22"},
+		Arr:      []InnerStruct{{A: 1}, {A: 2}},
+		Fixed:    [2]int{1, 2},
+		MapInt:   map[string]int{"a": 1, "b": 2},
+		MapKey:   map[int]string{1: "a"},
+		MapAny:   map[string]interface{}{"a": []interface{}{json.Number("1")}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("InstanceFromReader() = %+v, want %+v", got, want)
+	}
+}
+
+func TestInstanceFromReaderErr(t *testing.T) {
+	tests := []struct {
+		name string
+		dest interface{}
+		data string
+	}{
+		{name: "not_ptr", dest: streamFoo{}, data: `{}`},
+		{name: "bad_json", dest: &streamFoo{}, data: `{"id":`},
+		{name: "trailing", dest: &streamFoo{}, data: `{"id":1} garbage`},
+		{name: "trailing_value", dest: &streamFoo{}, data: `{"id":1} {"id":2}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := InstanceFromReader(tt.dest, strings.NewReader(tt.data)); err == nil {
+				t.Errorf("InstanceFromReader() want error")
+			}
+		})
+	}
+	if err := InstanceFromBytes(&streamFoo{}, []byte(`{"id":1} garbage`)); err == nil {
+		t.Errorf("InstanceFromBytes() trailing data want error")
+	}
+}
+
+type streamInline struct {
+	Name  string            `json:"name"`
+	Count int               `json:"count"`
+	Extra map[string]string `json:",inline"`
+}
+
+func TestInstanceFromReaderInline(t *testing.T) {
+	data := `{"name":"x","k":"v","count":2,"n":1}`
+	want := streamInline{Name: "x", Count: 2, Extra: map[string]string{"name": "x", "k": "v", "count": "2", "n": "1"}}
+
+	var mp map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &mp); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	var fromMap streamInline
+	if err := InstanceFromMap(&fromMap, mp); err != nil {
+		t.Fatalf("InstanceFromMap() error = %v", err)
+	}
+	if !reflect.DeepEqual(fromMap, want) {
+		t.Errorf("InstanceFromMap() = %+v, want %+v", fromMap, want)
+	}
+
+	var got streamInline
+	if err := InstanceFromReader(&got, strings.NewReader(data)); err != nil {
+		t.Fatalf("InstanceFromReader() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, fromMap) {
+		t.Errorf("InstanceFromReader() = %+v, InstanceFromMap() = %+v", got, fromMap)
+	}
+}
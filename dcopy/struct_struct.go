@@ -16,25 +16,110 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
+	"time"
 
 	"github.com/toolkits/slice"
 )
 
+// structCopyState 结构体拷贝过程中的统计
+type structCopyState struct {
+	optArgs       *args
+	hit           int
+	miss          int
+	unconvertible []string            // 类型不匹配且无法转换的字段
+	mapped        map[string]struct{} // 已按 WithFieldMapping 拷贝的目标字段路径
+	allowAll      bool                // 已匹配 WithOnlyFields，子字段全部拷贝
+	report        *CopyReport         // 字段拷贝明细
+	srcPath       string              // 当前来源结构体的字段路径
+	mappedSrc     map[string]struct{} // 已按 WithFieldMapping 使用的来源字段路径
+	// visited 已拷贝的来源指针，处理循环引用
+	visited map[visitKey]reflect.Value
+}
+
+func newStructCopyState(optArgs *args) *structCopyState {
+	return &structCopyState{
+		optArgs:   optArgs,
+		mapped:    map[string]struct{}{},
+		report:    &CopyReport{},
+		mappedSrc: map[string]struct{}{},
+		visited:   map[visitKey]reflect.Value{},
+	}
+}
+
+// WithConvertTypes StructCopy时字段类型不一致，是否按 InstanceFromMap 的规则转换
+// 例如 int -> string, string -> int64, time.Time -> string, int -> float64
+func WithConvertTypes(convert bool) CopyOption {
+	return func(a *args) {
+		a.convertTypes = convert
+	}
+}
+
+// WithStrict StructCopy时存在类型不匹配且无法转换的字段，则返回错误
+// 此时先拷贝到dest的副本，成功后才写回dest，返回错误时dest不变
+func WithStrict(strict bool) CopyOption {
+	return func(a *args) {
+		a.strict = strict
+	}
+}
+
+// WithOnlyFields StructCopy时只拷贝指定的字段，自动忽略大小写
+// 字段名可以是go字段名/tag字段名，嵌套字段使用路径，如 "Address.City"
+func WithOnlyFields(fieldNames ...string) CopyOption {
+	return func(a *args) {
+		tmp := make(map[string]struct{}, len(fieldNames))
+		for _, name := range fieldNames {
+			tmp[strings.ToLower(name)] = struct{}{}
+		}
+		a.onlyFieldMap = tmp
+	}
+}
+
+// WithSourceTag StructCopy时来源结构体按指定tag获取字段名，如 "gorm"
+// 默认按 WithFieldType 的规则
+func WithSourceTag(tag string) CopyOption {
+	return func(a *args) {
+		a.srcTag = tag
+	}
+}
+
+// WithDestTag StructCopy时目标结构体按指定tag获取字段名，如 "json"
+// 默认按 WithFieldType 的规则
+func WithDestTag(tag string) CopyOption {
+	return func(a *args) {
+		a.destTag = tag
+	}
+}
+
+// WithCaseInsensitive StructCopy时字段名匹配是否忽略大小写
+func WithCaseInsensitive(caseInsensitive bool) CopyOption {
+	return func(a *args) {
+		a.caseInsensitive = caseInsensitive
+	}
+}
+
 //
 // StructCopy 结构体同字段名（同字段类型）拷贝
-//  @Description:
+//  @Description: 先按字段名(tag规则同 getFieldTag，可通过 WithSourceTag/WithDestTag 指定)匹配，匹配不到再按go字段名匹配
 //  @param dest
 //  @param from
 func StructCopy(dest interface{}, from interface{}, opts ...CopyOption) error {
+	_, err := StructCopyWithReport(dest, from, opts...)
+	return err
+}
+
+// StructCopyWithReport 同 StructCopy，并返回字段拷贝明细
+// 可用于测试中检查DTO映射是否覆盖了全部字段
+func StructCopyWithReport(dest interface{}, from interface{}, opts ...CopyOption) (*CopyReport, error) {
 	optArgs := newOpts(opts...)
 	//
 	destValue := reflect.ValueOf(dest)
 	if destValue.Kind() != reflect.Ptr {
-		return errors.New("dest not ptr type")
+		return nil, errors.New("dest not ptr type")
 	} else {
 		destValue = destValue.Elem()
 		if destValue.Kind() != reflect.Struct {
-			return errors.New("dest not struct type")
+			return nil, errors.New("dest not struct type")
 		}
 	}
 
@@ -43,89 +128,503 @@ func StructCopy(dest interface{}, from interface{}, opts ...CopyOption) error {
 		fromValue = fromValue.Elem()
 	}
 	if fromValue.Kind() != reflect.Struct {
-		return errors.New("from not struct type")
+		return nil, errors.New("from not struct type")
 	}
 
-	hit, miss := structCopy(destValue, fromValue, optArgs)
-	printLog(&optArgs, 0, fmt.Sprintf("struct copy complete: hit(%d) miss(%d)", hit, miss))
-	return nil
+	fromValue = addressableValue(fromValue)
+	target := destValue
+	if optArgs.strict {
+		// 拷贝到副本，失败时不修改dest
+		cloneArgs := optArgs
+		cloneArgs.unexportedMode = UnexportedMode_Copy
+		target = reflect.New(destValue.Type()).Elem()
+		cloneValue(target, destValue, newCloneState(), &cloneArgs)
+	}
+	state := newStructCopyState(&optArgs)
+	if err := applyFieldMapping(target, fromValue, state); err != nil {
+		return nil, err
+	}
+	structCopy(target, fromValue, "", state)
+	printLog(&optArgs, 0, fmt.Sprintf("struct copy complete: hit(%d) miss(%d)", state.hit, state.miss))
+	report := state.report
+	report.TypeMismatch = state.unconvertible
+	if optArgs.strict && len(state.unconvertible) > 0 {
+		return report, fmt.Errorf("fields cannot be converted: %s", strings.Join(state.unconvertible, ", "))
+	}
+	if optArgs.strict {
+		destValue.Set(target)
+	}
+	return report, nil
 }
 
-func structCopy(dest, from reflect.Value, optArgs args) (hit, mis int) {
-
+func structCopy(dest, from reflect.Value, path string, state *structCopyState) {
+	optArgs := state.optArgs
+	fromFields := newFieldIndex(from.Type(), optArgs.srcTag, optArgs)
+	allowAll := state.allowAll
+	defer func() { state.allowAll = allowAll }()
+	consumed := make([][]int, 0, dest.NumField())
 	for i := 0; i < dest.NumField(); i++ {
+		state.allowAll = allowAll
 		destFieldType := dest.Type().Field(i)
 		destField := dest.Field(i)
 		fieldName := destFieldType.Name
-		if !destField.CanSet() {
-			mis += 1
+		fieldPath := joinFieldPath(path, fieldName)
+		if _, ok := state.mapped[fieldPath]; ok { // 已按 WithFieldMapping 拷贝
+			continue
+		}
+		if !state.filterField(destFieldType, path) {
+			continue
+		}
+		destField, ok := accessibleField(destField, destFieldType, optArgs)
+		if !ok || !destField.CanSet() {
+			state.miss += 1
+			state.report.UnexportedSkipped = append(state.report.UnexportedSkipped, fieldPath)
 			continue
 		}
 
-		if destField.Kind() == reflect.Ptr {
-			destField = destField.Elem()
+		index, ok := fromFields.lookup(destFieldType, optArgs)
+		if !ok { // 找不到字段
+			state.miss += 1
+			state.report.MissingInSource = append(state.report.MissingInSource, fieldPath)
+			continue
+		}
+		fromFieldType := from.Type().FieldByIndex(index)
+		_, _, ignore := structFieldTag(fromFieldType, optArgs.srcTag, optArgs)
+		if ignore {
+			continue
+		}
+		consumed = append(consumed, index)
+		fromField, ok := exposeValue(fieldByIndex(from, index))
+		if !ok || !fromField.IsValid() {
+			state.miss += 1
+			state.report.MissingInSource = append(state.report.MissingInSource, fieldPath)
+			continue
+		}
+		// 忽略0值，用于部分更新
+		if optArgs.omitempty && fromField.IsZero() {
+			continue
+		}
+		// 匿名组合的字段路径与外层相同
+		subPath, srcPath := fieldPath, state.srcPath
+		if destFieldType.Anonymous {
+			subPath = path
 		}
+		if !fromFieldType.Anonymous {
+			state.srcPath = joinFieldPath(srcPath, fromFieldType.Name)
+		}
+		copyField(destField, fromField, subPath, state)
+		state.srcPath = srcPath
+	}
+	state.addSourceUnused(from.Type(), fromFields, consumed)
+}
 
-		fromField := from.FieldByName(fieldName)
-		if !fromField.IsValid() { // 找不到字段
-			mis += 1
+// addSourceUnused 记录没有被拷贝到目标的来源字段
+func (s *structCopyState) addSourceUnused(tpe reflect.Type, fromFields *fieldIndex, consumed [][]int) {
+	for _, index := range fromFields.fields {
+		fieldType := tpe.FieldByIndex(index)
+		if s.skipSource(fieldType) || isIndexConsumed(index, consumed) {
 			continue
 		}
+		s.addUnusedPath(fieldType, joinFieldPath(s.srcPath, fieldType.Name))
+	}
+}
 
-		if fromField.Kind() == reflect.Ptr {
-			fromField = fromField.Elem()
+// addUnusedPath 记录未使用的来源字段
+// 部分子字段被 WithFieldMapping 使用的结构体，展开记录其余的子字段
+func (s *structCopyState) addUnusedPath(fieldType reflect.StructField, srcPath string) {
+	if _, ok := s.mappedSrc[srcPath]; ok {
+		return
+	}
+	tpe := fieldType.Type
+	for tpe.Kind() == reflect.Ptr {
+		tpe = tpe.Elem()
+	}
+	if tpe.Kind() != reflect.Struct || !isNestedStruct(tpe) || !isSubPathMapped(srcPath, s.mappedSrc) {
+		s.report.SourceUnused = append(s.report.SourceUnused, srcPath)
+		return
+	}
+	for _, index := range newFieldIndex(tpe, s.optArgs.srcTag, s.optArgs).fields {
+		sub := tpe.FieldByIndex(index)
+		if !s.skipSource(sub) {
+			s.addUnusedPath(sub, joinFieldPath(srcPath, sub.Name))
 		}
+	}
+}
 
-		// 如果数据类型不匹配则返回错误
-		if !isFieldTypeMatch(destField, fromField) {
-			//return errors.New("field type not match")
-			mis += 1
-			continue
+// skipSource 不参与拷贝的来源字段(未导出或ignore)
+func (s *structCopyState) skipSource(fieldType reflect.StructField) bool {
+	if isUnexportedField(fieldType) && s.optArgs.unexportedMode != UnexportedMode_Copy {
+		return true
+	}
+	_, _, ignore := structFieldTag(fieldType, s.optArgs.srcTag, s.optArgs)
+	return ignore
+}
+
+// isIndexConsumed index本身或所在的匿名组合字段已被拷贝
+func isIndexConsumed(index []int, consumed [][]int) bool {
+	for _, it := range consumed {
+		if len(it) <= len(index) && reflect.DeepEqual(it, index[:len(it)]) {
+			return true
 		}
+	}
+	return false
+}
 
-		switch destField.Kind() {
-		case reflect.Slice:
-			if e := sliceCopy(destField, fromField, optArgs); e == nil {
-				hit += 1
-			} else {
-				mis += 1
+// isSubPathMapped 字段的子字段已被 WithFieldMapping 使用
+func isSubPathMapped(path string, mapped map[string]struct{}) bool {
+	for it := range mapped {
+		if strings.HasPrefix(it, path+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// filterField 按 ignore tag, WithIgnoreFields, WithOnlyFields 判断目标字段是否需要拷贝
+// 字段名可以是go字段名/tag字段名/字段路径，忽略大小写
+func (s *structCopyState) filterField(fieldType reflect.StructField, path string) bool {
+	optArgs := s.optArgs
+	key, _, ignore := structFieldTag(fieldType, optArgs.destTag, optArgs)
+	if ignore {
+		return false
+	}
+	fieldPath := joinFieldPath(path, fieldType.Name)
+	names := fieldMatchNames(fieldType, key, path)
+	if containsFieldName(optArgs.ignoreFieldMap, names) {
+		return false
+	}
+	if s.allowAll || len(optArgs.onlyFieldMap) == 0 || fieldType.Anonymous {
+		return true
+	}
+	if containsFieldName(optArgs.onlyFieldMap, names) {
+		s.allowAll = true // 子字段全部拷贝
+		return true
+	}
+	// 指定了子字段，如 Address.City
+	prefix := strings.ToLower(fieldPath) + "."
+	for name := range optArgs.onlyFieldMap {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldMatchNames 字段可以被 WithIgnoreFields/WithOnlyFields 匹配的名字(小写)
+// go字段名、tag字段名，以及path(按go字段名拼接)下的字段路径
+func fieldMatchNames(fieldType reflect.StructField, key, path string) []string {
+	return []string{
+		strings.ToLower(fieldType.Name),
+		strings.ToLower(key),
+		strings.ToLower(joinFieldPath(path, fieldType.Name)),
+		strings.ToLower(joinFieldPath(path, key)),
+	}
+}
+
+func containsFieldName(set map[string]struct{}, names []string) bool {
+	for _, name := range names {
+		if _, ok := set[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// copyField 拷贝单个字段，类型不匹配时按 WithConvertTypes 尝试转换
+func copyField(destField, fromField reflect.Value, fieldPath string, state *structCopyState) {
+	ok := copyValue(destField, fromField, fieldPath, state)
+	if ok && isNestedStruct(destField.Type()) && isNestedStruct(fromField.Type()) {
+		return // 嵌套结构体按其字段统计
+	}
+	if ok {
+		state.hit += 1
+		state.report.Copied = append(state.report.Copied, fieldPath)
+	} else {
+		state.miss += 1
+	}
+}
+
+// copyValue 按类型深度拷贝，nil指针自动分配，slice/map按元素逐个拷贝，不与from共享
+// 返回false表示未拷贝(来源为nil指针或类型无法转换)
+func copyValue(dest, from reflect.Value, fieldPath string, state *structCopyState) bool {
+	if from.Kind() == reflect.Ptr {
+		if from.IsNil() {
+			return false
+		}
+		if dest.Kind() == reflect.Ptr {
+			return copyPtr(dest, from, fieldPath, state)
+		}
+		return copyValue(dest, from.Elem(), fieldPath, state)
+	}
+	if dest.Kind() == reflect.Ptr {
+		if !dest.IsNil() {
+			return copyValue(dest.Elem(), from, fieldPath, state)
+		}
+		it := reflect.New(dest.Type().Elem())
+		if !copyValue(it.Elem(), from, fieldPath, state) {
+			return false
+		}
+		dest.Set(it)
+		return true
+	}
+
+	// 数据类型不匹配时尝试转换
+	if !isFieldTypeMatch(dest.Type(), from.Type()) {
+		if !state.optArgs.convertTypes {
+			state.addUnconvertible(fieldPath)
+			return false
+		}
+		if err := convertCopy(dest, from, state.optArgs); err != nil {
+			printLog(state.optArgs, 0, "convert field:", fieldPath, "err:", err)
+			state.addUnconvertible(fieldPath)
+			return false
+		}
+		return true
+	}
+
+	switch dest.Kind() {
+	case reflect.Struct:
+		if isNestedStruct(dest.Type()) {
+			structCopy(dest, from, fieldPath, state)
+		} else if isBigType(dest.Type()) {
+			bigClone(dest, from)
+		} else {
+			dest.Set(from)
+		}
+	case reflect.Slice:
+		if from.Kind() == reflect.Slice && from.IsNil() {
+			dest.Set(reflect.Zero(dest.Type()))
+			return true
+		}
+		sl := reflect.MakeSlice(dest.Type(), from.Len(), from.Len())
+		elemState := state.elemState()
+		for i := 0; i < from.Len(); i++ {
+			copyValue(sl.Index(i), from.Index(i), fieldPath, elemState)
+		}
+		state.mergeElemState(elemState)
+		dest.Set(sl)
+	case reflect.Array:
+		elemState := state.elemState()
+		for i := 0; i < dest.Len() && i < from.Len(); i++ {
+			copyValue(dest.Index(i), from.Index(i), fieldPath, elemState)
+		}
+		state.mergeElemState(elemState)
+	case reflect.Map:
+		if from.IsNil() {
+			dest.Set(reflect.Zero(dest.Type()))
+			return true
+		}
+		tpe := dest.Type()
+		mp := reflect.MakeMapWithSize(tpe, from.Len())
+		elemState := state.elemState()
+		iter := from.MapRange()
+		for iter.Next() {
+			key := reflect.New(tpe.Key()).Elem()
+			if !copyValue(key, iter.Key(), fieldPath, elemState) {
+				continue
 			}
-		case reflect.Map:
-			if e := mapCopy(destField, fromField, optArgs); e == nil {
-				hit += 1
-			} else {
-				mis += 1
+			val := reflect.New(tpe.Elem()).Elem()
+			copyValue(val, iter.Value(), fieldPath, elemState)
+			mp.SetMapIndex(key, val)
+		}
+		state.mergeElemState(elemState)
+		dest.Set(mp)
+	case reflect.Interface:
+		it := reflect.New(from.Type()).Elem()
+		cloneValue(it, from, newCloneState(), state.optArgs)
+		dest.Set(it)
+	default:
+		if dest.Type() == from.Type() {
+			dest.Set(from)
+		} else {
+			basicCopy(dest, from, *state.optArgs)
+		}
+	}
+	return true
+}
+
+// copyPtr 拷贝指针，同一个来源指针只拷贝一次，循环引用及共享的指针复用已拷贝的对象
+func copyPtr(dest, from reflect.Value, fieldPath string, state *structCopyState) bool {
+	key := visitKey{ptr: from.Pointer(), tpe: dest.Type()}
+	if it, ok := state.visited[key]; ok {
+		dest.Set(it)
+		return true
+	}
+	it := dest
+	if dest.IsNil() {
+		it = reflect.New(dest.Type().Elem())
+	}
+	state.visited[key] = it
+	if !copyValue(it.Elem(), from.Elem(), fieldPath, state) {
+		delete(state.visited, key)
+		return false
+	}
+	dest.Set(it)
+	return true
+}
+
+func (s *structCopyState) addUnconvertible(fieldPath string) {
+	for _, it := range s.unconvertible {
+		if it == fieldPath {
+			return
+		}
+	}
+	s.unconvertible = append(s.unconvertible, fieldPath)
+}
+
+// elemState slice/map元素的拷贝不计入字段统计
+func (s *structCopyState) elemState() *structCopyState {
+	return &structCopyState{optArgs: s.optArgs, allowAll: s.allowAll, report: &CopyReport{}, visited: s.visited}
+}
+
+func (s *structCopyState) mergeElemState(elem *structCopyState) {
+	for _, it := range elem.unconvertible {
+		s.addUnconvertible(it)
+	}
+}
+
+// isNestedStruct 需要按字段拷贝的结构体，time.Time和big类型整体拷贝
+func isNestedStruct(tpe reflect.Type) bool {
+	for tpe.Kind() == reflect.Ptr {
+		tpe = tpe.Elem()
+	}
+	return tpe.Kind() == reflect.Struct && tpe != reflect.TypeOf(time.Time{}) && !isBigType(tpe)
+}
+
+// fieldIndex 结构体的字段索引，包含匿名组合提升的字段
+type fieldIndex struct {
+	tag    string
+	byKey  map[string][]int // tag字段名 -> 字段索引
+	byName map[string][]int // go字段名 -> 字段索引
+	fields [][]int          // 非匿名字段的索引，不包含被外层覆盖的字段
+}
+
+// newFieldIndex tag为空时字段名按 getFieldTag 的规则
+func newFieldIndex(tpe reflect.Type, tag string, optArgs *args) *fieldIndex {
+	out := &fieldIndex{tag: tag, byKey: map[string][]int{}, byName: map[string][]int{}}
+	out.visit(tpe, nil, optArgs, map[reflect.Type]bool{})
+	return out
+}
+
+// visit 外层字段优先，同 streamFieldIndex
+func (s *fieldIndex) visit(tpe reflect.Type, parent []int, optArgs *args, visited map[reflect.Type]bool) {
+	visited[tpe] = true
+	anonymous := make([]int, 0, 2)
+	for i := 0; i < tpe.NumField(); i++ {
+		fieldType := tpe.Field(i)
+		index := append(append([]int{}, parent...), i)
+		name := matchName(fieldType.Name, optArgs)
+		if s.byName[name] != nil {
+			continue
+		}
+		if isUnexportedField(fieldType) && optArgs.unexportedMode != UnexportedMode_Copy {
+			continue
+		}
+		s.byName[name] = index
+		if fieldType.Anonymous {
+			anonymous = append(anonymous, i)
+			continue
+		}
+		if key := structFieldKey(fieldType, s.tag, optArgs); s.byKey[key] == nil {
+			s.byKey[key] = index
+		}
+		s.fields = append(s.fields, index)
+	}
+	for _, i := range anonymous {
+		sub := tpe.Field(i).Type
+		if sub.Kind() == reflect.Ptr {
+			sub = sub.Elem()
+		}
+		if sub.Kind() != reflect.Struct || visited[sub] {
+			continue
+		}
+		s.visit(sub, append(append([]int{}, parent...), i), optArgs, visited)
+	}
+}
+
+// lookup 按目标字段查找来源字段索引，先按tag字段名，再按go字段名
+func (s *fieldIndex) lookup(destFieldType reflect.StructField, optArgs *args) ([]int, bool) {
+	index, ok := s.byKey[structFieldKey(destFieldType, optArgs.destTag, optArgs)]
+	if !ok || destFieldType.Anonymous {
+		index, ok = s.byName[matchName(destFieldType.Name, optArgs)]
+	}
+	return index, ok
+}
+
+// find 按go字段名或tag字段名查找字段索引
+func (s *fieldIndex) find(name string, optArgs *args) ([]int, bool) {
+	name = matchName(name, optArgs)
+	if index, ok := s.byName[name]; ok {
+		return index, ok
+	}
+	index, ok := s.byKey[name]
+	return index, ok
+}
+
+// fieldByIndex 同 FieldByIndex，途经nil指针时返回无效值
+func fieldByIndex(from reflect.Value, index []int) reflect.Value {
+	for i, idx := range index {
+		if i > 0 && from.Kind() == reflect.Ptr {
+			if from.IsNil() {
+				return reflect.Value{}
 			}
-		case reflect.Struct:
-			h, m := structCopy(destField, fromField, optArgs)
-			hit += h
-			mis += m
-		default:
-			basicCopy(destField, fromField, optArgs)
-			hit += 1
+			from = from.Elem()
 		}
+		from = from.Field(idx)
+	}
+	return from
+}
+
+// structFieldKey StructCopy匹配字段时使用的字段名
+// tag为空时按 getFieldTag 的规则
+func structFieldKey(field reflect.StructField, tag string, optArgs *args) string {
+	name, _, _ := structFieldTag(field, tag, optArgs)
+	return matchName(name, optArgs)
+}
+
+// structFieldTag tag为空时按 getFieldTag 的规则
+// return fieldname, omitempty, ignore
+func structFieldTag(field reflect.StructField, tag string, optArgs *args) (name string, omitempty, ignore bool) {
+	if tag != "" {
+		name, omitempty, ignore = parseTagName(field, tag)
+	} else {
+		name, omitempty, ignore = getFieldTag(field, optArgs)
+	}
+	if name == "" {
+		name = littleCamelCase(field.Name)
 	}
 	return
 }
 
-func mapCopy(dest, from reflect.Value, optArgs args) error {
-	makeMap := reflect.MakeMap(dest.Type())
-	iter := from.MapRange()
-	for iter.Next() {
-		k := iter.Key()
-		v := iter.Value()
+func matchName(name string, optArgs *args) string {
+	if optArgs.caseInsensitive {
+		return strings.ToLower(name)
+	}
+	return name
+}
 
-		makeMap.SetMapIndex(k, v)
+// convertCopy 将from转换成通用数据后，按 valueDeepCopy 的规则写入dest
+func convertCopy(dest, from reflect.Value, optArgs *args) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New(interface2String(r))
+		}
+	}()
+	v, err := toGenericValue(from, optArgs)
+	if err != nil {
+		return err
+	}
+	if err = checkConvertible(dest.Type(), v, optArgs); err != nil {
+		return err
 	}
-	dest.Set(makeMap)
-	return nil
+	return valueDeepCopy(dest, v, 0, "", optArgs)
 }
 
-func sliceCopy(dest, from reflect.Value, optArgs args) error {
-	makeSlice := reflect.MakeSlice(dest.Type(), from.Len(), from.Cap())
-	reflect.Copy(makeSlice, from)
-	dest.Set(makeSlice)
-	return nil
+func joinFieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
 }
 
 func basicCopy(dest, from reflect.Value, optArgs args) {
@@ -149,35 +648,51 @@ func basicCopy(dest, from reflect.Value, optArgs args) {
 }
 
 // isFieldTypeMatch 相识类型判断  int8,int16,int32,int64; uint8,uint16,uint32,uint64,int 归一类
-//  @Description:
+//  @Description: 指针按指向的类型判断，slice/map按元素类型递归判断
 //  @param destType
 //  @param fromType
 //  @return bool
-func isFieldTypeMatch(dest, from reflect.Value) bool {
+func isFieldTypeMatch(destType, fromType reflect.Type) bool {
 	intKind := []interface{}{reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64}
 	uintKind := []interface{}{reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64}
 	floatKind := []interface{}{reflect.Float64, reflect.Float32}
 
-	switch dest.Kind() {
+	for destType.Kind() == reflect.Ptr {
+		destType = destType.Elem()
+	}
+	for fromType.Kind() == reflect.Ptr {
+		fromType = fromType.Elem()
+	}
+	if destType == fromType {
+		return true
+	}
+	switch destType.Kind() {
 	case reflect.String:
-		return from.Kind() == reflect.String
+		return fromType.Kind() == reflect.String
+	case reflect.Bool:
+		return fromType.Kind() == reflect.Bool
 	case reflect.Float32, reflect.Float64:
-		return slice.Contains(floatKind, from.Kind())
+		return slice.Contains(floatKind, fromType.Kind())
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return slice.Contains(intKind, from.Kind())
+		return slice.Contains(intKind, fromType.Kind())
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return slice.Contains(uintKind, from.Kind())
-	case reflect.Slice:
-		if from.Kind() == reflect.Slice {
-			return dest.Type().Elem() == from.Type().Elem()
+		return slice.Contains(uintKind, fromType.Kind())
+	case reflect.Struct:
+		// 不同类型的结构体按字段拷贝，time.Time/big类型必须同类型
+		return isNestedStruct(destType) && isNestedStruct(fromType)
+	case reflect.Slice, reflect.Array:
+		if fromType.Kind() == reflect.Slice || fromType.Kind() == reflect.Array {
+			return isFieldTypeMatch(destType.Elem(), fromType.Elem())
 		}
 		return false
 	case reflect.Map:
-		if from.Kind() == reflect.Map {
-			return dest.Type().Elem() == from.Type().Elem()
+		if fromType.Kind() == reflect.Map {
+			return isFieldTypeMatch(destType.Key(), fromType.Key()) && isFieldTypeMatch(destType.Elem(), fromType.Elem())
 		}
 		return false
+	case reflect.Interface:
+		return fromType.Implements(destType)
 	default:
-		return dest.Kind() == from.Kind()
+		return false
 	}
 }
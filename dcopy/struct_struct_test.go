@@ -12,7 +12,12 @@
 
 package dcopy
 
-import "testing"
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
 
 func TestStructFromStruct(t *testing.T) {
 
@@ -72,3 +77,508 @@ func TestStructFromStruct(t *testing.T) {
 		})
 	}
 }
+
+func TestStructCopyConvertTypes(t *testing.T) {
+	type Src struct {
+		Int    int
+		Str    string
+		Bad    string
+		Time   time.Time
+		IntPtr *int
+		Float  int
+		Bool   string
+	}
+	type Dst struct {
+		Int    string
+		Str    int64
+		Bad    int
+		Time   string
+		IntPtr int
+		Float  float64
+		Bool   bool
+	}
+	num := 7
+	now := time.Date(2022, 12, 5, 17, 9, 0, 0, time.Local)
+	src := Src{Int: 1, Str: "123", Bad: "abc", Time: now, IntPtr: &num, Float: 2, Bool: "true"}
+
+	tests := []struct {
+		name    string
+		opts    []CopyOption
+		want    Dst
+		wantErr bool
+	}{
+		{
+			name: "no_convert",
+			opts: nil,
+			want: Dst{IntPtr: 7},
+		},
+		{
+			name: "convert",
+			opts: []CopyOption{WithConvertTypes(true)},
+			want: Dst{Int: "1", Str: 123, Time: "2022-12-05 17:09:00", IntPtr: 7, Float: 2, Bool: true},
+		},
+		{
+			name:    "convert_strict",
+			opts:    []CopyOption{WithConvertTypes(true), WithStrict(true)},
+			want:    Dst{}, // 返回错误时不修改dest
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Dst{}
+			err := StructCopy(&got, src, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("StructCopy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "Bad") {
+				t.Errorf("StructCopy() error = %v, want field Bad reported", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StructCopy() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	// strict成功时写回dest，未拷贝的字段保持不变
+	got := Dst{Bad: 1}
+	if err := StructCopy(&got, src, WithStrict(true), WithOnlyFields("IntPtr")); err != nil {
+		t.Fatalf("StructCopy() error = %v", err)
+	}
+	if want := (Dst{Bad: 1, IntPtr: 7}); got != want {
+		t.Errorf("StructCopy() = %+v, want %+v", got, want)
+	}
+}
+
+func TestStructCopyByTag(t *testing.T) {
+	type Base struct {
+		Id int64 `gorm:"column:id"`
+	}
+	type Entity struct {
+		Base
+		UserID   int64  `gorm:"column:user_id"`
+		NickName string `gorm:"column:nick_name"`
+		Email    string
+	}
+	type DTO struct {
+		Id    int64
+		Uid   int64  `json:"user_id"`
+		Nick  string `json:"nick_name"`
+		EMAIL string
+	}
+	src := Entity{Base: Base{Id: 9}, UserID: 100, NickName: "nick", Email: "a@b.c"}
+
+	tests := []struct {
+		name string
+		opts []CopyOption
+		want DTO
+	}{
+		{
+			name: "default",
+			want: DTO{Id: 9, Uid: 100, Nick: "nick"},
+		},
+		{
+			name: "source_dest_tag",
+			opts: []CopyOption{WithSourceTag("gorm"), WithDestTag("json")},
+			want: DTO{Id: 9, Uid: 100, Nick: "nick"},
+		},
+		{
+			name: "case_insensitive",
+			opts: []CopyOption{WithSourceTag("gorm"), WithDestTag("json"), WithCaseInsensitive(true)},
+			want: DTO{Id: 9, Uid: 100, Nick: "nick", EMAIL: "a@b.c"},
+		},
+		{
+			name: "origin",
+			opts: []CopyOption{WithFieldType(FieldType_Origin)},
+			want: DTO{Id: 9},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DTO{}
+			if err := StructCopy(&got, &src, tt.opts...); err != nil {
+				t.Fatalf("StructCopy() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StructCopy() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStructCopyFieldMapping(t *testing.T) {
+	type Profile struct {
+		Age int
+	}
+	type Src struct {
+		Name     string
+		CityName string `json:"city_name"`
+		Profile  *Profile
+	}
+	type Address struct {
+		City string
+	}
+	type Dst struct {
+		FullName string
+		Name     string
+		Age      int
+		Address  *Address
+	}
+
+	tests := []struct {
+		name    string
+		from    Src
+		mapping map[string]string
+		want    Dst
+		wantErr bool
+	}{
+		{
+			name: "rename_flatten_unflatten",
+			from: Src{Name: "tom", CityName: "sh", Profile: &Profile{Age: 18}},
+			mapping: map[string]string{
+				"Dst.FullName":     "Src.Name",
+				"Dst.Address.City": "Src.city_name",
+				"Age":              "Profile.Age",
+			},
+			want: Dst{FullName: "tom", Name: "tom", Age: 18, Address: &Address{City: "sh"}},
+		},
+		{
+			name: "mapped_field_skips_name_matching",
+			from: Src{Name: "tom", CityName: "sh"},
+			mapping: map[string]string{
+				"Dst.Name": "Src.CityName",
+				"Dst.Age":  "Src.Profile.Age",
+			},
+			want: Dst{Name: "sh"},
+		},
+		{
+			name:    "unknown_field",
+			from:    Src{Name: "tom"},
+			mapping: map[string]string{"Dst.Nick": "Src.Name"},
+			want:    Dst{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Dst{}
+			err := StructCopy(&got, tt.from, WithFieldMapping(tt.mapping))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("StructCopy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StructCopy() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStructCopyFieldMappingReport(t *testing.T) {
+	type Profile struct {
+		Age int
+	}
+	type Src struct {
+		Name    string
+		Profile *Profile
+	}
+	type Dst struct {
+		Age      int
+		internal string
+	}
+	mapping := WithFieldMapping(map[string]string{"internal": "Name", "Age": "Profile.Age"})
+
+	// 来源路径途经nil指针时同nil字段，不算找不到
+	got := Dst{}
+	report, err := StructCopyWithReport(&got, Src{Name: "tom"}, mapping, WithUnexported(UnexportedMode_Copy))
+	if err != nil {
+		t.Fatalf("StructCopyWithReport() error = %v", err)
+	}
+	if got.internal != "tom" || !reflect.DeepEqual(report.Copied, []string{"internal"}) || len(report.MissingInSource) != 0 {
+		t.Errorf("StructCopyWithReport() = %+v, %+v", got, report)
+	}
+
+	// 未导出的目标字段默认找不到
+	if _, err := StructCopyWithReport(&Dst{}, Src{Name: "tom"}, mapping); err == nil {
+		t.Errorf("StructCopyWithReport() want error for unexported mapping")
+	}
+}
+
+func TestStructCopyDeep(t *testing.T) {
+	type SrcItem struct {
+		Name string
+		Tags []string
+	}
+	type DstItem struct {
+		Name string
+		Tags []string
+	}
+	type SrcAddr struct {
+		City string
+	}
+	type DstAddr struct {
+		City string
+	}
+	type Src struct {
+		Address *SrcAddr
+		Home    SrcAddr
+		Items   []SrcItem
+		ItemMap map[string]*SrcItem
+		Nums    map[string]int
+		Ptrs    []*SrcItem
+	}
+	type Dst struct {
+		Address *DstAddr
+		Home    *DstAddr
+		Items   []DstItem
+		ItemMap map[string]DstItem
+		Nums    map[string]int64
+		Ptrs    []*DstItem
+	}
+	src := Src{
+		Address: &SrcAddr{City: "sh"},
+		Home:    SrcAddr{City: "bj"},
+		Items:   []SrcItem{{Name: "a", Tags: []string{"x"}}, {Name: "b"}},
+		ItemMap: map[string]*SrcItem{"k": {Name: "c", Tags: []string{"y"}}},
+		Nums:    map[string]int{"n": 1},
+		Ptrs:    []*SrcItem{{Name: "d"}, nil},
+	}
+	want := Dst{
+		Address: &DstAddr{City: "sh"},
+		Home:    &DstAddr{City: "bj"},
+		Items:   []DstItem{{Name: "a", Tags: []string{"x"}}, {Name: "b"}},
+		ItemMap: map[string]DstItem{"k": {Name: "c", Tags: []string{"y"}}},
+		Nums:    map[string]int64{"n": 1},
+		Ptrs:    []*DstItem{{Name: "d"}, nil},
+	}
+
+	got := Dst{}
+	if err := StructCopy(&got, src); err != nil {
+		t.Fatalf("StructCopy() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("StructCopy() = %+v, want %+v", got, want)
+	}
+
+	// 不与来源共享数据
+	src.Items[0].Tags[0] = "changed"
+	src.ItemMap["k"].Tags[0] = "changed"
+	src.Address.City = "changed"
+	if got.Items[0].Tags[0] != "x" || got.ItemMap["k"].Tags[0] != "y" || got.Address.City != "sh" {
+		t.Errorf("StructCopy() result aliases source: %+v", got)
+	}
+
+	// 同类型的引用字段同样深度拷贝
+	type Same struct {
+		Tags []string
+		Kvs  map[string][]int
+	}
+	from := Same{Tags: []string{"a"}, Kvs: map[string][]int{"k": {1}}}
+	to := Same{}
+	if err := StructCopy(&to, from); err != nil {
+		t.Fatalf("StructCopy() error = %v", err)
+	}
+	from.Tags[0] = "b"
+	from.Kvs["k"][0] = 2
+	if to.Tags[0] != "a" || to.Kvs["k"][0] != 1 {
+		t.Errorf("StructCopy() result aliases source: %+v", to)
+	}
+}
+
+func TestStructCopyCycle(t *testing.T) {
+	type SrcNode struct {
+		Name string
+		Next *SrcNode
+	}
+	type DstNode struct {
+		Name string
+		Next *DstNode
+	}
+	type Src struct {
+		Head  *SrcNode
+		Alias *SrcNode
+	}
+	type Dst struct {
+		Head  *DstNode
+		Alias *DstNode
+	}
+
+	self := &SrcNode{Name: "self"}
+	self.Next = self
+	ring := &SrcNode{Name: "a", Next: &SrcNode{Name: "b"}}
+	ring.Next.Next = ring
+
+	got := Dst{}
+	if err := StructCopy(&got, Src{Head: self, Alias: self}); err != nil {
+		t.Fatalf("StructCopy() error = %v", err)
+	}
+	if got.Head == nil || got.Head.Name != "self" || got.Head.Next != got.Head || got.Alias != got.Head {
+		t.Errorf("StructCopy() self cycle = %+v", got)
+	}
+
+	got = Dst{}
+	if err := StructCopy(&got, Src{Head: ring}); err != nil {
+		t.Fatalf("StructCopy() error = %v", err)
+	}
+	if got.Head == nil || got.Head.Next == nil || got.Head.Next.Name != "b" || got.Head.Next.Next != got.Head {
+		t.Errorf("StructCopy() ring = %+v", got)
+	}
+
+	// 同类型同样复用已拷贝的对象，且不与来源共享
+	same := SrcNode{}
+	if err := StructCopy(&same, self); err != nil {
+		t.Fatalf("StructCopy() error = %v", err)
+	}
+	if same.Next == nil || same.Next == self || same.Next.Next != same.Next {
+		t.Errorf("StructCopy() same type cycle = %+v", same)
+	}
+}
+
+func TestStructCopyFilter(t *testing.T) {
+	type Address struct {
+		City string
+		Zip  string `json:"zip_code"`
+	}
+	type User struct {
+		Name     string
+		Age      int
+		Password string `json:"-"`
+		Address  Address
+	}
+	type UserPatch struct {
+		Name     string
+		Age      int `json:"age,omitempty"` // 来源的omitempty不影响拷贝，只有 WithOmitempty 才忽略0值
+		Password string
+		Address  Address
+	}
+	entity := func() User {
+		return User{Name: "tom", Age: 18, Password: "secret", Address: Address{City: "sh", Zip: "200000"}}
+	}
+	patch := UserPatch{Name: "jerry", Password: "new", Address: Address{Zip: "100000"}}
+
+	tests := []struct {
+		name string
+		opts []CopyOption
+		want User
+	}{
+		{
+			name: "ignore_tag",
+			want: User{Name: "jerry", Password: "secret", Address: Address{Zip: "100000"}},
+		},
+		{
+			name: "ignore_fields",
+			opts: []CopyOption{WithIgnoreFields("name", "Address.zip_code")},
+			want: User{Name: "tom", Password: "secret", Address: Address{Zip: "200000"}},
+		},
+		{
+			name: "omitempty",
+			opts: []CopyOption{WithOmitempty(true)},
+			want: User{Name: "jerry", Age: 18, Password: "secret", Address: Address{City: "sh", Zip: "100000"}},
+		},
+		{
+			name: "only_fields",
+			opts: []CopyOption{WithOnlyFields("Age", "address.Zip")},
+			want: User{Name: "tom", Password: "secret", Address: Address{City: "sh", Zip: "100000"}},
+		},
+		{
+			name: "only_struct_field",
+			opts: []CopyOption{WithOnlyFields("Address")},
+			want: User{Name: "tom", Age: 18, Password: "secret", Address: Address{Zip: "100000"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := entity()
+			if err := StructCopy(&got, patch, tt.opts...); err != nil {
+				t.Fatalf("StructCopy() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StructCopy() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStructCopyWithReport(t *testing.T) {
+	type Base struct {
+		ID int64
+	}
+	type Src struct {
+		Base
+		Name    string
+		Age     string
+		Extra   string
+		Profile struct {
+			Nick  string
+			Email string
+		}
+		secret string
+	}
+	type Dst struct {
+		ID      int64
+		Name    string
+		Age     int
+		Phone   string
+		Profile struct {
+			Nick string
+		}
+		NickName string
+		internal string
+	}
+	src := Src{Base: Base{ID: 1}, Name: "tom", Age: "x", secret: "s"}
+	src.Profile.Nick = "t"
+
+	got := Dst{}
+	report, err := StructCopyWithReport(&got, src, WithFieldMapping(map[string]string{"NickName": "Profile.Nick"}))
+	if err != nil {
+		t.Fatalf("StructCopyWithReport() error = %v", err)
+	}
+	want := &CopyReport{
+		Copied:            []string{"NickName", "ID", "Name", "Profile.Nick"},
+		MissingInSource:   []string{"Phone"},
+		TypeMismatch:      []string{"Age"},
+		UnexportedSkipped: []string{"internal"},
+		SourceUnused:      []string{"Profile.Email", "Extra"},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("StructCopyWithReport() = %+v, want %+v", report, want)
+	}
+	if report.FullCoverage() {
+		t.Errorf("FullCoverage() = true, want false")
+	}
+
+	full, err := StructCopyWithReport(&Base{}, Base{ID: 2})
+	if err != nil || !full.FullCoverage() {
+		t.Errorf("StructCopyWithReport() = %+v, %v, want full coverage", full, err)
+	}
+	// 扁平化时只有映射了的子字段视为已使用
+	type User struct {
+		Name    string
+		Profile *struct {
+			Age int
+			Bio string
+			Tag struct {
+				Color string
+				Size  int
+			}
+		}
+	}
+	type UserDTO struct {
+		Name  string
+		Age   int
+		Color string
+	}
+	user := User{Name: "tom"}
+	flat, err := StructCopyWithReport(&UserDTO{}, user, WithFieldMapping(map[string]string{
+		"Age":   "Profile.Age",
+		"Color": "Profile.Tag.Color",
+	}))
+	if err != nil {
+		t.Fatalf("StructCopyWithReport() error = %v", err)
+	}
+	if want := []string{"Profile.Bio", "Profile.Tag.Size"}; !reflect.DeepEqual(flat.SourceUnused, want) {
+		t.Errorf("StructCopyWithReport() SourceUnused = %v, want %v", flat.SourceUnused, want)
+	}
+	if flat.FullCoverage() {
+		t.Errorf("FullCoverage() = true, want false")
+	}
+}
@@ -0,0 +1,69 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: unexported.go
+ * @time: 2026/10/19 20:10
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+type UnexportedMode int8
+
+const (
+	UnexportedMode_Skip UnexportedMode = 0 + iota // 跳过未导出字段
+	UnexportedMode_Copy                           // 通过unsafe读写未导出字段
+)
+
+// WithUnexported 未导出字段的处理方式，默认跳过
+// UnexportedMode_Copy 用于 StructCopy/InstanceCopy/Clone/InstanceToMap 拷贝私有状态(如内部缓存)，
+// 要求字段可寻址，传入指针或由内部复制一份
+func WithUnexported(mode UnexportedMode) CopyOption {
+	return func(a *args) {
+		a.unexportedMode = mode
+	}
+}
+
+// isUnexportedField 未导出的非匿名字段
+func isUnexportedField(fieldType reflect.StructField) bool {
+	return fieldType.PkgPath != "" && !fieldType.Anonymous
+}
+
+// accessibleField 返回可以调用Interface()/Set()的字段值
+// 未导出字段只有 UnexportedMode_Copy 时可访问；匿名组合(包括未导出的)提升的导出字段总是可访问
+func accessibleField(field reflect.Value, fieldType reflect.StructField, optArgs *args) (reflect.Value, bool) {
+	if isUnexportedField(fieldType) && optArgs.unexportedMode != UnexportedMode_Copy {
+		return field, false
+	}
+	return exposeValue(field)
+}
+
+// exposeValue 去掉通过未导出字段获取的值的只读限制，不可寻址时返回false
+func exposeValue(field reflect.Value) (reflect.Value, bool) {
+	if !field.IsValid() || field.CanInterface() {
+		return field, true
+	}
+	if !field.CanAddr() {
+		return field, false
+	}
+	return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem(), true
+}
+
+// addressableValue 不可寻址的结构体复制一份，保证字段可寻址
+func addressableValue(v reflect.Value) reflect.Value {
+	if v.CanAddr() || v.Kind() != reflect.Struct || !v.CanInterface() {
+		return v
+	}
+	tmp := reflect.New(v.Type()).Elem()
+	tmp.Set(v)
+	return tmp
+}
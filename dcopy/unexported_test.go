@@ -0,0 +1,105 @@
+/**
+ * @version: v0.1.0
+ * @author: zhangguodong
+ * @license: LGPL v3
+ * @contact: general_zgd@163.com
+ * @site: github.com/generalzgd
+ * @software: GoLand
+ * @file: unexported_test.go
+ * @time: 2026/10/19 20:30
+ * @project: deepcopy
+ */
+
+package dcopy
+
+import (
+	"reflect"
+	"testing"
+)
+
+type unexportedMeta struct {
+	Version int
+}
+
+type unexportedCache struct {
+	unexportedMeta
+	Name  string
+	cache map[string]int
+	count int
+	ptr   *int
+}
+
+func TestUnexportedSkip(t *testing.T) {
+	from := unexportedCache{unexportedMeta: unexportedMeta{Version: 2}, Name: "a", cache: map[string]int{"k": 1}, count: 3}
+
+	out, err := InstanceToMap(from)
+	if err != nil {
+		t.Fatalf("InstanceToMap() error = %v", err)
+	}
+	if want := map[string]interface{}{"version": int64(2), "name": "a"}; !reflect.DeepEqual(out, want) {
+		t.Errorf("InstanceToMap() = %v, want %v", out, want)
+	}
+
+	if v := GetFieldValue(from, "count"); v != nil {
+		t.Errorf("GetFieldValue() = %v, want nil", v)
+	}
+	if v := GetFieldValue(&from, "Version"); v != 2 {
+		t.Errorf("GetFieldValue() = %v, want 2", v)
+	}
+	if values := GetFieldsValue(from, false, FieldType_Origin, nil); !reflect.DeepEqual(values, []interface{}{2, "a"}) {
+		t.Errorf("GetFieldsValue() = %v", values)
+	}
+	if names := GetFieldsTagName(from, FieldType_Origin, nil); !reflect.DeepEqual(names, []string{"Version", "Name"}) {
+		t.Errorf("GetFieldsTagName() = %v", names)
+	}
+
+	dest := unexportedCache{}
+	if err := StructCopy(&dest, from); err != nil {
+		t.Fatalf("StructCopy() error = %v", err)
+	}
+	if dest.Name != "a" || dest.Version != 2 || dest.cache != nil || dest.count != 0 {
+		t.Errorf("StructCopy() = %+v", dest)
+	}
+
+	// Clone 同样不拷贝未导出字段，不与来源共享
+	n := 1
+	from.ptr = &n
+	cp := Clone(from)
+	if cp.Name != "a" || cp.Version != 2 || cp.cache != nil || cp.count != 0 || cp.ptr != nil {
+		t.Errorf("Clone() = %+v", cp)
+	}
+	from.cache["k"] = 2
+	n = 2
+	if cp.cache["k"] != 0 || cp.ptr != nil {
+		t.Errorf("Clone() shares unexported fields with source: %+v", cp)
+	}
+}
+
+func TestUnexportedCopy(t *testing.T) {
+	from := &unexportedCache{Name: "a", cache: map[string]int{"k": 1}, count: 3}
+
+	dest := unexportedCache{}
+	if err := StructCopy(&dest, from, WithUnexported(UnexportedMode_Copy)); err != nil {
+		t.Fatalf("StructCopy() error = %v", err)
+	}
+	if !reflect.DeepEqual(&dest, from) {
+		t.Errorf("StructCopy() = %+v, want %+v", dest, *from)
+	}
+
+	cp := Clone(from, WithUnexported(UnexportedMode_Copy))
+	if !reflect.DeepEqual(cp, from) {
+		t.Errorf("Clone() = %+v, want %+v", *cp, *from)
+	}
+	from.cache["k"] = 2
+	if cp.cache["k"] != 1 || dest.cache["k"] != 1 {
+		t.Errorf("unexported map not deep copied: %v %v", cp.cache, dest.cache)
+	}
+
+	out, err := InstanceToMap(from, WithUnexported(UnexportedMode_Copy), WithFieldType(FieldType_Origin))
+	if err != nil {
+		t.Fatalf("InstanceToMap() error = %v", err)
+	}
+	if interface2Int64(out["count"]) != 3 {
+		t.Errorf("InstanceToMap() = %v", out)
+	}
+}
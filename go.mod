@@ -1,6 +1,10 @@
 module github.com/generalzgd/deepcopy
 
-go 1.14
+go 1.23
 
 //github.com/generalzgd/comm-libs v0.0.0-20200419072240-8400406771df
 require github.com/toolkits/slice v0.0.0-20141116085117-e44a80af2484
+
+require github.com/sirupsen/logrus v1.10.2
+
+require golang.org/x/sys v0.13.0 // indirect